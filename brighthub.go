@@ -1,31 +1,80 @@
 package brighthub
 
 import (
+	"bytes"
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/http"
+	"sync"
 	"time"
 
 	log "github.com/sirupsen/logrus"
 )
 
 type (
+	// TokenSource provide OAuth access token, it is shared between clients of the same account
+	TokenSource interface {
+		AccessToken() (string, error)
+	}
+
+	// Client :nodoc:
 	Client interface {
+		TokenSource
+
+		// WithContext return a copy of the client which send its requests with ctx, sharing the access token
+		WithContext(ctx context.Context) Client
+
 		AddVideoToFolder(videoID, folderID string) error
+		ListFolders() ([]*Folder, error)
+		CreateFolder(name string) (*Folder, error)
+		ListPlaylists(limit, offset int) ([]*Playlist, error)
+		GetVideoFields() (*VideoFields, error)
 		CreateVideo(req *CreateVideoRequest) (*CreateVideoResponse, error)
+		GetVideo(videoID string) (*Video, error)
+		ListVideos(query *VideoQuery) ([]*Video, error)
+		ListScheduledVideos(from, to time.Time) (*ScheduledVideos, error)
+		GetVideoByReferenceID(referenceID string) (*Video, error)
+		UpdateVideo(videoID string, req *UpdateVideoRequest) (*Video, error)
+		UpsertVideo(req *CreateVideoRequest) (*UpsertResult, error)
+		ReplaceCuePoints(videoID string, cuePoints []*CuePoint) (*Video, error)
+		ApplyGeoRestriction(query string, geo *VideoGeo) (*BulkGeoResult, error)
 		GetIngestProfile(id string) (*IngestProfile, error)
 		IngestVideo(videoID string, req *IngestVideoRequest) (*IngestVideoResponse, error)
+		PreflightIngestVideo(req *IngestVideoRequest) (*IngestPreflightReport, error)
+		Retranscode(videoID, profile string) (*IngestVideoResponse, error)
+		GetIngestJob(videoID, jobID string) (*IngestJob, error)
 		GetVideoMasterInfo(videoID string) (*VideoMasterInfo, error)
+		DeleteDigitalMaster(videoID string) error
+		GetVideoSources(videoID string) ([]*VideoSource, error)
+		GetVideoRenditions(videoID string) ([]*VideoRendition, error)
+		GetVideoDynamicRenditions(videoID string) ([]*DynamicRendition, error)
+
+		ListIngestProfiles() ([]*IngestProfile, error)
+		CreateIngestProfile(profile *IngestProfile) (*IngestProfile, error)
+		UpdateIngestProfile(profile *IngestProfile) (*IngestProfile, error)
+		DeleteIngestProfile(id string) error
+		GetDefaultIngestProfile() (*IngestProfileConfiguration, error)
+		SetDefaultIngestProfile(id string) (*IngestProfileConfiguration, error)
 	}
 
 	client struct {
+		*accessTokenCache
+		accountID    string
+		clientID     string
+		clientSecret string
+		httpClient   *http.Client
+		ctx          context.Context
+		breakers     *circuitBreakers
+	}
+
+	// accessTokenCache shared by the copies of the client
+	accessTokenCache struct {
+		mu                    sync.Mutex
 		accessToken           string
 		accessTokenAcquiredAt time.Time
-		accountID             string
-		clientID              string
-		clientSecret          string
-		httpClient            *http.Client
 	}
 
 	getAccessTokenResponse struct {
@@ -43,16 +92,20 @@ var (
 )
 
 // New :nodoc:
-func New(clientID, clientSecret, accountID string, httpClient *http.Client) (Client, error) {
+func New(clientID, clientSecret, accountID string, httpClient *http.Client, opts ...Option) (Client, error) {
 	c := &client{
-		accountID:    accountID,
-		clientID:     clientID,
-		clientSecret: clientSecret,
-		httpClient:   httpClient,
+		accessTokenCache: new(accessTokenCache),
+		accountID:        accountID,
+		clientID:         clientID,
+		clientSecret:     clientSecret,
+		httpClient:       httpClient,
 	}
 	if httpClient == nil {
 		c.httpClient = defaultHTTPClient
 	}
+	for _, opt := range opts {
+		opt(c)
+	}
 
 	_, err := c.getAccessToken()
 	if err != nil {
@@ -65,7 +118,29 @@ func New(clientID, clientSecret, accountID string, httpClient *http.Client) (Cli
 	return c, nil
 }
 
+// WithContext :nodoc:
+func (c *client) WithContext(ctx context.Context) Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+func (c *client) context() context.Context {
+	if c.ctx == nil {
+		return context.Background()
+	}
+	return c.ctx
+}
+
+// AccessToken :nodoc:
+func (c *client) AccessToken() (string, error) {
+	return c.getAccessToken()
+}
+
 func (c *client) getAccessToken() (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	// Access Token only valid for 5 minutes. If > 5 minutes then get another token and update.
 	// Since we cannot sure, therefore make a 1 minute buffer.
 	if time.Since(c.accessTokenAcquiredAt).Minutes() <= 4 {
@@ -80,10 +155,11 @@ func (c *client) getAccessToken() (string, error) {
 			Error(err)
 		return "", err
 	}
+	req = req.WithContext(c.context())
 	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.clientID+":"+c.clientSecret)))
 
-	resp, err := c.httpClient.Do(req)
+	resp, err := c.do(req)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"client_id":     c.clientID,
@@ -107,3 +183,30 @@ func (c *client) getAccessToken() (string, error) {
 	c.accessTokenAcquiredAt = time.Now()
 	return a.AccessToken, nil
 }
+
+// newRequest creates an authorized request, body will be encoded as JSON when not nil
+func (c *client) newRequest(method, url string, body interface{}) (*http.Request, error) {
+	token, err := c.getAccessToken()
+	if err != nil {
+		return nil, err
+	}
+
+	var b io.Reader
+	if body != nil {
+		buf := new(bytes.Buffer)
+		err = json.NewEncoder(buf).Encode(body)
+		if err != nil {
+			return nil, err
+		}
+		b = buf
+	}
+
+	r, err := http.NewRequest(method, url, b)
+	if err != nil {
+		return nil, err
+	}
+	r = r.WithContext(c.context())
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+token)
+	return r, nil
+}
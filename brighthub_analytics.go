@@ -0,0 +1,345 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// AnalyticsClient client for Brightcove Analytics API
+	AnalyticsClient interface {
+		GetReport(query *ReportQuery) (*Report, error)
+		IterateReport(query *ReportQuery) *ReportIterator
+		GetAccountEngagement(dateRange *DateRange) (*Engagement, error)
+		GetPlayerEngagement(playerID string, dateRange *DateRange) (*Engagement, error)
+		GetVideoEngagement(videoID string, dateRange *DateRange) (*Engagement, error)
+	}
+
+	analyticsClient struct {
+		accountID   string
+		tokenSource TokenSource
+		httpClient  *http.Client
+	}
+
+	// Dimension analytics report dimension
+	Dimension string
+
+	// ReportQuery :nodoc:
+	ReportQuery struct {
+		Dimensions []Dimension
+		Fields     []string
+		Where      []*ReportFilter
+		// Sort field name, prefix with - for descending order
+		Sort      string
+		DateRange *DateRange
+		Limit     int
+		Offset    int
+	}
+
+	// ReportFilter only include rows whose dimension value is one of Values
+	ReportFilter struct {
+		Dimension Dimension
+		Values    []string
+	}
+
+	// DateRange zero From or To use the Analytics API default
+	DateRange struct {
+		From time.Time
+		To   time.Time
+	}
+
+	// Report :nodoc:
+	Report struct {
+		ItemCount int64        `json:"item_count"`
+		Items     []ReportItem `json:"items"`
+		Summary   ReportItem   `json:"summary"`
+	}
+
+	// ReportItem report row keyed by dimension and field name
+	ReportItem map[string]interface{}
+
+	// Engagement :nodoc:
+	Engagement struct {
+		Account       string              `json:"account"`
+		Player        string              `json:"player,omitempty"`
+		Video         string              `json:"video,omitempty"`
+		VideoDuration SecondsDuration     `json:"video_duration"`
+		Timeline      *EngagementTimeline `json:"timeline"`
+	}
+
+	// EngagementTimeline :nodoc:
+	EngagementTimeline struct {
+		Type   string    `json:"type"`
+		Values []float64 `json:"values"`
+	}
+
+	// ReportIterator iterate every item of a report, fetching the next page when needed
+	ReportIterator struct {
+		client *analyticsClient
+		query  ReportQuery
+		items  []ReportItem
+		item   ReportItem
+		total  int64
+		done   bool
+		err    error
+	}
+)
+
+const (
+	// DimensionVideo :nodoc:
+	DimensionVideo Dimension = "video"
+	// DimensionPlayer :nodoc:
+	DimensionPlayer Dimension = "player"
+	// DimensionDate :nodoc:
+	DimensionDate Dimension = "date"
+	// DimensionCountry :nodoc:
+	DimensionCountry Dimension = "country"
+	// DimensionDeviceType :nodoc:
+	DimensionDeviceType Dimension = "device_type"
+
+	// FieldVideoView :nodoc:
+	FieldVideoView = "video_view"
+	// FieldVideoImpression :nodoc:
+	FieldVideoImpression = "video_impression"
+	// FieldPlayRate :nodoc:
+	FieldPlayRate = "play_rate"
+	// FieldEngagementScore :nodoc:
+	FieldEngagementScore = "engagement_score"
+	// FieldVideoSecondsViewed :nodoc:
+	FieldVideoSecondsViewed = "video_seconds_viewed"
+	// FieldVideoPercentViewed :nodoc:
+	FieldVideoPercentViewed = "video_percent_viewed"
+
+	defaultReportLimit = 100
+)
+
+var analyticsBaseURL = "https://analytics.api.brightcove.com/v1"
+
+// NewAnalyticsClient create analytics client, pass Client as tokenSource to share its access token
+func NewAnalyticsClient(accountID string, tokenSource TokenSource, httpClient *http.Client) AnalyticsClient {
+	c := &analyticsClient{
+		accountID:   accountID,
+		tokenSource: tokenSource,
+		httpClient:  httpClient,
+	}
+	if httpClient == nil {
+		c.httpClient = defaultHTTPClient
+	}
+	return c
+}
+
+// GetReport get a single page of report
+func (c *analyticsClient) GetReport(query *ReportQuery) (*Report, error) {
+	params := url.Values{}
+	params.Set("accounts", c.accountID)
+	if len(query.Dimensions) > 0 {
+		dimensions := make([]string, len(query.Dimensions))
+		for i, d := range query.Dimensions {
+			dimensions[i] = string(d)
+		}
+		params.Set("dimensions", strings.Join(dimensions, ","))
+	}
+	if len(query.Fields) > 0 {
+		params.Set("fields", strings.Join(query.Fields, ","))
+	}
+	if len(query.Where) > 0 {
+		filters := make([]string, len(query.Where))
+		for i, f := range query.Where {
+			filters[i] = fmt.Sprintf("%s==%s", f.Dimension, strings.Join(f.Values, ","))
+		}
+		params.Set("where", strings.Join(filters, ";"))
+	}
+	if query.Sort != "" {
+		params.Set("sort", query.Sort)
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+	setDateRangeParams(params, query.DateRange)
+
+	report := new(Report)
+	err := c.get("/data", params, report)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"query": utils.Dump(query)}).
+			Error(err)
+		return nil, err
+	}
+	return report, nil
+}
+
+// IterateReport iterate every item of the report starting from query.Offset
+func (c *analyticsClient) IterateReport(query *ReportQuery) *ReportIterator {
+	it := &ReportIterator{
+		client: c,
+		query:  *query,
+	}
+	if it.query.Limit <= 0 {
+		it.query.Limit = defaultReportLimit
+	}
+	return it
+}
+
+// GetAccountEngagement :nodoc:
+func (c *analyticsClient) GetAccountEngagement(dateRange *DateRange) (*Engagement, error) {
+	params := url.Values{}
+	setDateRangeParams(params, dateRange)
+
+	engagement := new(Engagement)
+	err := c.get(fmt.Sprintf("/engagement/accounts/%s", c.accountID), params, engagement)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return engagement, nil
+}
+
+// GetPlayerEngagement :nodoc:
+func (c *analyticsClient) GetPlayerEngagement(playerID string, dateRange *DateRange) (*Engagement, error) {
+	params := url.Values{}
+	setDateRangeParams(params, dateRange)
+
+	engagement := new(Engagement)
+	err := c.get(fmt.Sprintf("/engagement/accounts/%s/players/%s", c.accountID, playerID), params, engagement)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID}).
+			Error(err)
+		return nil, err
+	}
+	return engagement, nil
+}
+
+// GetVideoEngagement :nodoc:
+func (c *analyticsClient) GetVideoEngagement(videoID string, dateRange *DateRange) (*Engagement, error) {
+	params := url.Values{}
+	setDateRangeParams(params, dateRange)
+
+	engagement := new(Engagement)
+	err := c.get(fmt.Sprintf("/engagement/accounts/%s/videos/%s", c.accountID, videoID), params, engagement)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+	return engagement, nil
+}
+
+func (c *analyticsClient) get(path string, params url.Values, out interface{}) error {
+	token, err := c.tokenSource.AccessToken()
+	if err != nil {
+		return err
+	}
+
+	u := analyticsBaseURL + path
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	r, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return ErrBadRequest
+		case http.StatusUnauthorized:
+			return ErrUnauthorized
+		case http.StatusForbidden:
+			return ErrNotAvailable
+		case http.StatusNotFound:
+			return ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return ErrInternalError
+		default:
+			return fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// setDateRangeParams dates are sent as epoch milliseconds
+func setDateRangeParams(params url.Values, dateRange *DateRange) {
+	if dateRange == nil {
+		return
+	}
+	if !dateRange.From.IsZero() {
+		params.Set("from", strconv.FormatInt(dateRange.From.UnixNano()/int64(time.Millisecond), 10))
+	}
+	if !dateRange.To.IsZero() {
+		params.Set("to", strconv.FormatInt(dateRange.To.UnixNano()/int64(time.Millisecond), 10))
+	}
+}
+
+// Next advance to the next item, return false when there is no more item or an error occurred
+func (it *ReportIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if len(it.items) == 0 {
+		if it.done {
+			return false
+		}
+		report, err := it.client.GetReport(&it.query)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.total = report.ItemCount
+		it.items = report.Items
+		it.query.Offset += len(report.Items)
+		if len(report.Items) < it.query.Limit || int64(it.query.Offset) >= it.total {
+			it.done = true
+		}
+		if len(it.items) == 0 {
+			return false
+		}
+	}
+
+	it.item = it.items[0]
+	it.items = it.items[1:]
+	return true
+}
+
+// Item current item
+func (it *ReportIterator) Item() ReportItem {
+	return it.item
+}
+
+// Err error which stopped the iteration
+func (it *ReportIterator) Err() error {
+	return it.err
+}
+
+// ItemCount total item of the report, known after the first call of Next
+func (it *ReportIterator) ItemCount() int64 {
+	return it.total
+}
+
+// Offset offset of the next item, use it as query.Offset to resume the iteration
+func (it *ReportIterator) Offset() int {
+	return it.query.Offset - len(it.items)
+}
@@ -0,0 +1,117 @@
+package brighthub
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+// ExportFormat :nodoc:
+type ExportFormat string
+
+const (
+	// ExportFormatCSV :nodoc:
+	ExportFormatCSV ExportFormat = "csv"
+	// ExportFormatNDJSON newline delimited JSON
+	ExportFormatNDJSON ExportFormat = "ndjson"
+)
+
+// ExportReport write every item of the report to w page by page, so only a single page is held in memory.
+// It returns the offset of the next item not written yet, a failed export can be resumed by
+// calling ExportReport again with that offset as query.Offset.
+// CSV columns are the query dimensions followed by the query fields, header is only written when query.Offset is 0.
+func ExportReport(ac AnalyticsClient, query *ReportQuery, format ExportFormat, w io.Writer) (int, error) {
+	var writeItem func(item ReportItem) error
+	switch format {
+	case ExportFormatCSV:
+		writeItem = newCSVItemWriter(w, query)
+	case ExportFormatNDJSON:
+		encoder := json.NewEncoder(w)
+		writeItem = func(item ReportItem) error {
+			return encoder.Encode(item)
+		}
+	default:
+		return query.Offset, ErrUnsupportedExportFormat
+	}
+
+	it := ac.IterateReport(query)
+	offset := query.Offset
+	for it.Next() {
+		err := writeItem(it.Item())
+		if err != nil {
+			log.WithFields(log.Fields{
+				"query":  utils.Dump(query),
+				"offset": offset}).
+				Error(err)
+			return offset, err
+		}
+		offset++
+	}
+	if err := it.Err(); err != nil {
+		log.WithFields(log.Fields{
+			"query":  utils.Dump(query),
+			"offset": offset}).
+			Error(err)
+		return offset, err
+	}
+
+	return offset, nil
+}
+
+func newCSVItemWriter(w io.Writer, query *ReportQuery) func(item ReportItem) error {
+	writer := csv.NewWriter(w)
+	var columns []string
+	for _, d := range query.Dimensions {
+		columns = append(columns, string(d))
+	}
+	columns = append(columns, query.Fields...)
+	headerWritten := query.Offset > 0
+
+	return func(item ReportItem) error {
+		if len(columns) == 0 {
+			// columns are not specified in query, use whatever returned by Brightcove
+			for k := range item {
+				columns = append(columns, k)
+			}
+			sort.Strings(columns)
+		}
+		if !headerWritten {
+			if err := writer.Write(columns); err != nil {
+				return err
+			}
+			headerWritten = true
+		}
+
+		record := make([]string, len(columns))
+		for i, c := range columns {
+			record[i] = formatCSVValue(item[c])
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+		// flush every item, so the returned offset always match what is written
+		writer.Flush()
+		return writer.Error()
+	}
+}
+
+func formatCSVValue(v interface{}) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case float64:
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(value)
+	default:
+		b, _ := json.Marshal(value)
+		return string(b)
+	}
+}
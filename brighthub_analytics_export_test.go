@@ -0,0 +1,119 @@
+package brighthub
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newAnalyticsExportMock(failAtOffset int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		if failAtOffset > 0 && offset == failAtOffset {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		if offset >= 4 {
+			io.WriteString(w, `{"item_count": 5, "items": [{"video": "4", "video_view": 40, "play_rate": 0.4}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"item_count": 5, "items": [{"video": "%d", "video_view": %d, "play_rate": 0.%d}, {"video": "%d", "video_view": %d, "play_rate": 0.%d}]}`,
+			offset, offset*10, offset, offset+1, (offset+1)*10, offset+1)
+	}))
+}
+
+func TestExportReport(t *testing.T) {
+	query := &ReportQuery{
+		Dimensions: []Dimension{DimensionVideo},
+		Fields:     []string{FieldVideoView, FieldPlayRate},
+		Limit:      2,
+	}
+
+	t.Run("CSV", func(t *testing.T) {
+		httpMock := newAnalyticsExportMock(0)
+		defer httpMock.Close()
+		analyticsBaseURL = httpMock.URL // change for test
+
+		ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+		buf := new(bytes.Buffer)
+		offset, err := ExportReport(ac, query, ExportFormatCSV, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, offset)
+		assert.Equal(t, "video,video_view,play_rate\n0,0,0\n1,10,0.1\n2,20,0.2\n3,30,0.3\n4,40,0.4\n", buf.String())
+	})
+
+	t.Run("NDJSON", func(t *testing.T) {
+		httpMock := newAnalyticsExportMock(0)
+		defer httpMock.Close()
+		analyticsBaseURL = httpMock.URL // change for test
+
+		ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+		buf := new(bytes.Buffer)
+		offset, err := ExportReport(ac, &ReportQuery{Limit: 2, Offset: 4}, ExportFormatNDJSON, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, offset)
+		assert.Equal(t, "{\"play_rate\":0.4,\"video\":\"4\",\"video_view\":40}\n", buf.String())
+	})
+
+	t.Run("Resume", func(t *testing.T) {
+		httpMock := newAnalyticsExportMock(2)
+		analyticsBaseURL = httpMock.URL // change for test
+
+		ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+		buf := new(bytes.Buffer)
+		offset, err := ExportReport(ac, query, ExportFormatCSV, buf)
+		assert.Equal(t, ErrInternalError, err)
+		assert.Equal(t, 2, offset)
+		httpMock.Close()
+
+		httpMock = newAnalyticsExportMock(0)
+		defer httpMock.Close()
+		analyticsBaseURL = httpMock.URL // change for test
+
+		ac = NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+		resumeQuery := *query
+		resumeQuery.Offset = offset
+		offset, err = ExportReport(ac, &resumeQuery, ExportFormatCSV, buf)
+		assert.NoError(t, err)
+		assert.Equal(t, 5, offset)
+		assert.Equal(t, "video,video_view,play_rate\n0,0,0\n1,10,0.1\n2,20,0.2\n3,30,0.3\n4,40,0.4\n", buf.String())
+	})
+
+	t.Run("Writer error", func(t *testing.T) {
+		httpMock := newAnalyticsExportMock(0)
+		defer httpMock.Close()
+		analyticsBaseURL = httpMock.URL // change for test
+
+		ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+		offset, err := ExportReport(ac, query, ExportFormatNDJSON, &failingWriter{failAfter: 3})
+		assert.Error(t, err)
+		assert.Equal(t, 3, offset)
+	})
+
+	t.Run("Unsupported format", func(t *testing.T) {
+		_, err := ExportReport(NewAnalyticsClient("account-id", newClientMock(), nil), query, "xml", new(bytes.Buffer))
+		assert.Equal(t, ErrUnsupportedExportFormat, err)
+	})
+}
+
+type failingWriter struct {
+	failAfter int
+	written   int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if w.written >= w.failAfter {
+		return 0, errors.New("disk penuh")
+	}
+	w.written++
+	return len(p), nil
+}
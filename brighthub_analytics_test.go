@@ -0,0 +1,161 @@
+package brighthub
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAnalyticsClient_GetReport(t *testing.T) {
+	from := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2019, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	bh := newClientMock()
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/data", r.URL.Path)
+		assert.Equal(t, "Bearer "+bh.accessToken, r.Header.Get("Authorization"))
+		q := r.URL.Query()
+		assert.Equal(t, bh.accountID, q.Get("accounts"))
+		assert.Equal(t, "video,country", q.Get("dimensions"))
+		assert.Equal(t, "video_view,play_rate", q.Get("fields"))
+		assert.Equal(t, "country==ID;video==1,2", q.Get("where"))
+		assert.Equal(t, "-video_view", q.Get("sort"))
+		assert.Equal(t, "10", q.Get("limit"))
+		assert.Equal(t, "1556668800000", q.Get("from"))
+		assert.Equal(t, "1559347200000", q.Get("to"))
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{
+			"item_count": 2,
+			"items": [
+				{"video": "1", "country": "ID", "video_view": 120, "play_rate": 0.5},
+				{"video": "2", "country": "ID", "video_view": 80, "play_rate": 0.25}
+			],
+			"summary": {"video_view": 200, "play_rate": 0.375}
+		}`)
+	}))
+	defer httpMock.Close()
+	analyticsBaseURL = httpMock.URL // change for test
+
+	ac := NewAnalyticsClient(bh.accountID, bh, httpMock.Client())
+	report, err := ac.GetReport(&ReportQuery{
+		Dimensions: []Dimension{DimensionVideo, DimensionCountry},
+		Fields:     []string{FieldVideoView, FieldPlayRate},
+		Where: []*ReportFilter{
+			{Dimension: DimensionCountry, Values: []string{"ID"}},
+			{Dimension: DimensionVideo, Values: []string{"1", "2"}},
+		},
+		Sort:      "-" + FieldVideoView,
+		DateRange: &DateRange{From: from, To: to},
+		Limit:     10,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 2, report.ItemCount)
+	assert.Equal(t, "1", report.Items[0]["video"])
+	assert.EqualValues(t, 120, report.Items[0]["video_view"])
+	assert.EqualValues(t, 200, report.Summary["video_view"])
+}
+
+func TestAnalyticsClient_IterateReport(t *testing.T) {
+	var offsets []string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+		w.WriteHeader(http.StatusOK)
+		if offset >= 4 {
+			io.WriteString(w, `{"item_count": 5, "items": [{"video": "4"}]}`)
+			return
+		}
+		fmt.Fprintf(w, `{"item_count": 5, "items": [{"video": "%d"}, {"video": "%d"}]}`, offset, offset+1)
+	}))
+	defer httpMock.Close()
+	analyticsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	ac := NewAnalyticsClient(bh.accountID, bh, httpMock.Client())
+
+	t.Run("All", func(t *testing.T) {
+		offsets = nil
+		it := ac.IterateReport(&ReportQuery{Dimensions: []Dimension{DimensionVideo}, Limit: 2})
+		var videos []interface{}
+		for it.Next() {
+			videos = append(videos, it.Item()["video"])
+		}
+		assert.NoError(t, it.Err())
+		assert.Equal(t, []interface{}{"0", "1", "2", "3", "4"}, videos)
+		assert.EqualValues(t, 5, it.ItemCount())
+		assert.Equal(t, 5, it.Offset())
+		assert.Equal(t, []string{"", "2", "4"}, offsets)
+	})
+
+	t.Run("Resume from offset", func(t *testing.T) {
+		it := ac.IterateReport(&ReportQuery{Dimensions: []Dimension{DimensionVideo}, Limit: 2})
+		assert.True(t, it.Next())
+		assert.True(t, it.Next())
+		assert.True(t, it.Next())
+		assert.Equal(t, 3, it.Offset())
+
+		it = ac.IterateReport(&ReportQuery{Dimensions: []Dimension{DimensionVideo}, Limit: 2, Offset: it.Offset()})
+		assert.True(t, it.Next())
+		assert.Equal(t, "3", it.Item()["video"])
+	})
+}
+
+func TestAnalyticsClient_GetVideoEngagement(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/engagement/accounts/account-id/videos/12345", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{
+			"account": "account-id",
+			"video": "12345",
+			"video_duration": 31.4,
+			"timeline": {"type": "percent", "values": [100, 95.5, 80]}
+		}`)
+	}))
+	defer httpMock.Close()
+	analyticsBaseURL = httpMock.URL // change for test
+
+	ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+	engagement, err := ac.GetVideoEngagement("12345", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", engagement.Video)
+	assert.Equal(t, 31400*time.Millisecond, engagement.VideoDuration.Duration)
+	assert.Equal(t, "percent", engagement.Timeline.Type)
+	assert.Equal(t, []float64{100, 95.5, 80}, engagement.Timeline.Values)
+}
+
+func TestAnalyticsClient_GetPlayerEngagement(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/engagement/accounts/account-id/players/default", r.URL.Path)
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer httpMock.Close()
+	analyticsBaseURL = httpMock.URL // change for test
+
+	ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+	_, err := ac.GetPlayerEngagement("default", nil)
+	assert.Equal(t, ErrTooManyRequest, err)
+}
+
+func TestAnalyticsClient_GetAccountEngagement(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/engagement/accounts/account-id", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"account": "account-id", "timeline": {"type": "percent", "values": [100, 50]}}`)
+	}))
+	defer httpMock.Close()
+	analyticsBaseURL = httpMock.URL // change for test
+
+	ac := NewAnalyticsClient("account-id", newClientMock(), httpMock.Client())
+	engagement, err := ac.GetAccountEngagement(&DateRange{From: time.Now().Add(-24 * time.Hour)})
+	assert.NoError(t, err)
+	assert.Equal(t, "account-id", engagement.Account)
+}
@@ -0,0 +1,469 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// BackupManifest describe a backup directory, it is written last so a backup without manifest is incomplete
+	BackupManifest struct {
+		FormatVersion int                    `json:"format_version"`
+		AccountID     string                 `json:"account_id"`
+		CreatedAt     Timestamp              `json:"created_at"`
+		Files         map[string]*BackupFile `json:"files"`
+	}
+
+	// BackupFile JSONL file of an entity
+	BackupFile struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	}
+
+	// RestoreOptions :nodoc:
+	RestoreOptions struct {
+		// DryRun only report what would be changed
+		DryRun bool
+		// VideoIDs restore only these videos, matched by the backup video id or reference id, empty to restore all
+		VideoIDs []string
+		// Fields restore only these fields, using UpdateVideoRequest JSON names plus folder_id, empty to restore all
+		Fields []string
+	}
+
+	// RestoreItem diff of a backup video and the existing video
+	RestoreItem struct {
+		BackupVideoID string
+		VideoID       string
+		ReferenceID   string
+		// Changed JSON names of the restored fields
+		Changed []string
+		Err     error
+	}
+
+	// RestoreReport :nodoc:
+	RestoreReport struct {
+		DryRun bool
+		Items  []*RestoreItem
+	}
+)
+
+const (
+	// BackupFormatVersion version of the backup written by BackupAccount
+	BackupFormatVersion = 1
+
+	// BackupEntityVideos :nodoc:
+	BackupEntityVideos = "videos"
+	// BackupEntityFolders :nodoc:
+	BackupEntityFolders = "folders"
+	// BackupEntityPlaylists :nodoc:
+	BackupEntityPlaylists = "playlists"
+	// BackupEntityCustomFields custom field definitions
+	BackupEntityCustomFields = "custom_fields"
+
+	backupManifestName     = "manifest.json"
+	listPlaylistsPageLimit = 100
+)
+
+// BackupAccount write metadata of every video, folder, playlist and custom field definition of the account
+// into dir, as a JSONL file per entity plus manifest.json. Use a new dir for every backup.
+func BackupAccount(c Client, dir string) (*BackupManifest, error) {
+	err := os.MkdirAll(dir, 0755)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"dir": dir}).
+			Error(err)
+		return nil, err
+	}
+
+	manifest := &BackupManifest{
+		FormatVersion: BackupFormatVersion,
+		CreatedAt:     Timestamp{Time: time.Now().UTC()},
+		Files:         map[string]*BackupFile{},
+	}
+	backups := []struct {
+		entity string
+		write  func(enc *json.Encoder) (int, error)
+	}{
+		{BackupEntityVideos, func(enc *json.Encoder) (int, error) {
+			return backupVideos(c, enc, manifest)
+		}},
+		{BackupEntityFolders, func(enc *json.Encoder) (int, error) {
+			folders, err := c.ListFolders()
+			if err != nil {
+				return 0, err
+			}
+			for _, f := range folders {
+				if err := enc.Encode(f); err != nil {
+					return 0, err
+				}
+			}
+			return len(folders), nil
+		}},
+		{BackupEntityPlaylists, func(enc *json.Encoder) (int, error) {
+			return backupPlaylists(c, enc)
+		}},
+		{BackupEntityCustomFields, func(enc *json.Encoder) (int, error) {
+			fields, err := c.GetVideoFields()
+			if err != nil {
+				return 0, err
+			}
+			for _, f := range fields.CustomFields {
+				if err := enc.Encode(f); err != nil {
+					return 0, err
+				}
+			}
+			return len(fields.CustomFields), nil
+		}},
+	}
+
+	for _, b := range backups {
+		file := &BackupFile{Name: b.entity + ".jsonl"}
+		file.Count, err = writeBackupFile(filepath.Join(dir, file.Name), b.write)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"dir":    dir,
+				"entity": b.entity}).
+				Error(err)
+			return nil, err
+		}
+		manifest.Files[b.entity] = file
+	}
+
+	_, err = writeBackupFile(filepath.Join(dir, backupManifestName), func(enc *json.Encoder) (int, error) {
+		enc.SetIndent("", "  ")
+		return 1, enc.Encode(manifest)
+	})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"dir": dir}).
+			Error(err)
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeBackupFile(path string, write func(enc *json.Encoder) (int, error)) (int, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return 0, err
+	}
+	count, err := write(json.NewEncoder(f))
+	if err != nil {
+		f.Close()
+		return 0, err
+	}
+	return count, f.Close()
+}
+
+func backupVideos(c Client, enc *json.Encoder, manifest *BackupManifest) (int, error) {
+	count := 0
+	q := &VideoQuery{Sort: "created_at", Limit: listVideosPageLimit}
+	for {
+		videos, err := c.ListVideos(q)
+		if err != nil {
+			return count, err
+		}
+		for _, v := range videos {
+			if manifest.AccountID == "" {
+				manifest.AccountID = v.AccountID
+			}
+			if err := enc.Encode(v); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(videos) < q.Limit {
+			return count, nil
+		}
+		q.Offset += len(videos)
+	}
+}
+
+func backupPlaylists(c Client, enc *json.Encoder) (int, error) {
+	count := 0
+	for {
+		playlists, err := c.ListPlaylists(listPlaylistsPageLimit, count)
+		if err != nil {
+			return count, err
+		}
+		for _, p := range playlists {
+			if err := enc.Encode(p); err != nil {
+				return count, err
+			}
+			count++
+		}
+
+		if len(playlists) < listPlaylistsPageLimit {
+			return count, nil
+		}
+	}
+}
+
+// ReadBackupManifest :nodoc:
+func ReadBackupManifest(dir string) (*BackupManifest, error) {
+	f, err := os.Open(filepath.Join(dir, backupManifestName))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	manifest := new(BackupManifest)
+	err = json.NewDecoder(f).Decode(manifest)
+	if err != nil {
+		return nil, err
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > BackupFormatVersion {
+		return nil, ErrUnsupportedBackupVersion
+	}
+	return manifest, nil
+}
+
+// RestoreAccount replace video metadata of the existing videos with the backup in dir, metadata added after
+// the backup is removed. Videos are matched by id, then by reference id when the id is not found. Folders, playlists and custom field definitions are not restored,
+// and a video is not removed from its folder when it had none in the backup.
+// Failed videos don't stop the others, their error is in the report.
+func RestoreAccount(c Client, dir string, opts *RestoreOptions) (*RestoreReport, error) {
+	if opts == nil {
+		opts = new(RestoreOptions)
+	}
+	manifest, err := ReadBackupManifest(dir)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"dir": dir}).
+			Error(err)
+		return nil, err
+	}
+
+	videosFile, ok := manifest.Files[BackupEntityVideos]
+	if !ok {
+		return nil, ErrUnsupportedBackupVersion
+	}
+	f, err := os.Open(filepath.Join(dir, videosFile.Name))
+	if err != nil {
+		log.WithFields(log.Fields{
+			"dir": dir}).
+			Error(err)
+		return nil, err
+	}
+	defer f.Close()
+
+	selectedVideos := map[string]bool{}
+	for _, id := range opts.VideoIDs {
+		selectedVideos[id] = true
+	}
+	selectedFields := map[string]bool{}
+	for _, name := range opts.Fields {
+		selectedFields[name] = true
+	}
+
+	report := &RestoreReport{DryRun: opts.DryRun}
+	dec := json.NewDecoder(f)
+	for {
+		backup := new(Video)
+		err := dec.Decode(backup)
+		if err == io.EOF {
+			return report, nil
+		}
+		if err != nil {
+			log.WithFields(log.Fields{
+				"dir": dir}).
+				Error(err)
+			return report, err
+		}
+		if len(selectedVideos) > 0 && !selectedVideos[backup.ID] && !selectedVideos[backup.ReferenceID] {
+			continue
+		}
+
+		item := restoreVideo(c, backup, selectedFields, opts.DryRun)
+		if item.Err != nil {
+			log.WithFields(log.Fields{
+				"videoID": backup.ID}).
+				Error(item.Err)
+		}
+		report.Items = append(report.Items, item)
+	}
+}
+
+func restoreVideo(c Client, backup *Video, fields map[string]bool, dryRun bool) *RestoreItem {
+	item := &RestoreItem{BackupVideoID: backup.ID, ReferenceID: backup.ReferenceID}
+
+	video, err := c.GetVideo(backup.ID)
+	if err == ErrResourceNotFound && backup.ReferenceID != "" {
+		video, err = c.GetVideoByReferenceID(backup.ReferenceID)
+	}
+	if err != nil {
+		item.Err = err
+		return item
+	}
+	item.VideoID = video.ID
+
+	update, changed := restoreDiff(video, backup)
+	if backup.ReferenceID != "" && backup.ReferenceID != video.ReferenceID {
+		update.ReferenceID = backup.ReferenceID
+		changed = append(changed, "reference_id")
+	}
+	if backup.FolderID != "" && backup.FolderID != video.FolderID {
+		changed = append(changed, "folder_id")
+	}
+	if len(fields) > 0 {
+		update, changed = selectUpdateFields(update, changed, fields)
+	}
+	item.Changed = changed
+	if dryRun || len(changed) == 0 {
+		return item
+	}
+
+	restoreFolder := false
+	for _, name := range changed {
+		if name == "folder_id" {
+			restoreFolder = true
+		}
+	}
+	// every other changed field is in the update request
+	if len(changed) > 1 || !restoreFolder {
+		_, err = c.UpdateVideo(video.ID, update)
+		if err != nil {
+			item.Err = err
+			return item
+		}
+	}
+	if restoreFolder {
+		item.Err = c.AddVideoToFolder(video.ID, backup.FolderID)
+	}
+	return item
+}
+
+// restoreDiff update request which replace the video metadata with the backup, unlike diffVideo metadata
+// missing from the backup is removed: tags, cue points, schedule, geo and custom fields the backup doesn't have
+func restoreDiff(v *Video, backup *Video) (*UpdateVideoRequest, []string) {
+	update := new(UpdateVideoRequest)
+	var changed []string
+
+	if backup.Name != "" && backup.Name != v.Name {
+		update.Name = backup.Name
+		changed = append(changed, "name")
+	}
+	if backup.Description != v.Description {
+		update.Description = &backup.Description
+		changed = append(changed, "description")
+	}
+	if backup.LongDescription != v.LongDescription {
+		update.LongDescription = &backup.LongDescription
+		changed = append(changed, "long_description")
+	}
+	if backup.State != "" && backup.State != v.State {
+		update.State = backup.State
+		changed = append(changed, "state")
+	}
+	if !sameTags(backup.Tags, v.Tags) {
+		update.Tags = append([]string{}, backup.Tags...)
+		changed = append(changed, "tags")
+	}
+
+	customFields := map[string]string{}
+	for k, value := range backup.CustomFields {
+		if v.CustomFields[k] != value {
+			customFields[k] = value
+		}
+	}
+	for k, value := range v.CustomFields {
+		if _, ok := backup.CustomFields[k]; !ok && value != "" {
+			customFields[k] = ""
+		}
+	}
+	if len(customFields) > 0 {
+		update.CustomFields = customFields
+		changed = append(changed, "custom_fields")
+	}
+
+	if !sameCuePoints(backup.CuePoints, v.CuePoints) {
+		update.CuePoints = []*CuePoint{}
+		for _, cp := range backup.CuePoints {
+			c := *cp
+			c.ID = ""
+			update.CuePoints = append(update.CuePoints, &c)
+		}
+		changed = append(changed, "cue_points")
+	}
+
+	schedule := backup.Schedule
+	if schedule == nil {
+		schedule = new(VideoSchedule)
+	}
+	if !sameSchedule(schedule, v.Schedule) {
+		update.Schedule = schedule
+		changed = append(changed, "schedule")
+	}
+	geo := backup.Geo
+	if geo == nil {
+		geo = new(VideoGeo)
+	}
+	if !sameGeo(geo, v.Geo) {
+		update.Geo = geo
+		changed = append(changed, "geo")
+	}
+	return update, changed
+}
+
+// selectUpdateFields keep only the selected fields of the update request
+func selectUpdateFields(update *UpdateVideoRequest, changed []string, fields map[string]bool) (*UpdateVideoRequest, []string) {
+	selected := new(UpdateVideoRequest)
+	var names []string
+	for _, name := range changed {
+		if !fields[name] {
+			continue
+		}
+		names = append(names, name)
+
+		switch name {
+		case "name":
+			selected.Name = update.Name
+		case "description":
+			selected.Description = update.Description
+		case "long_description":
+			selected.LongDescription = update.LongDescription
+		case "reference_id":
+			selected.ReferenceID = update.ReferenceID
+		case "state":
+			selected.State = update.State
+		case "tags":
+			selected.Tags = update.Tags
+		case "custom_fields":
+			selected.CustomFields = update.CustomFields
+		case "cue_points":
+			selected.CuePoints = update.CuePoints
+		case "schedule":
+			selected.Schedule = update.Schedule
+		case "geo":
+			selected.Geo = update.Geo
+		}
+	}
+	return selected, names
+}
+
+// Write restore report as table, a line per video
+func (r *RestoreReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if r.DryRun {
+		fmt.Fprintln(tw, "DRY RUN, nothing was changed")
+	}
+	fmt.Fprintln(tw, "BACKUP_ID\tVIDEO_ID\tREFERENCE_ID\tCHANGED\tERROR")
+	for _, item := range r.Items {
+		errMessage := ""
+		if item.Err != nil {
+			errMessage = item.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", item.BackupVideoID, item.VideoID, item.ReferenceID,
+			strings.Join(item.Changed, ","), errMessage)
+	}
+	return tw.Flush()
+}
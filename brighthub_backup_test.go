@@ -0,0 +1,190 @@
+package brighthub
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackupAccount(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/videos"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `[
+				{"id": "1", "account_id": "acc", "name": "Video 1", "reference_id": "ref-1", "tags": ["news"]},
+				{"id": "2", "account_id": "acc", "name": "Video 2", "reference_id": "ref-2"}
+			]`)
+		case strings.HasSuffix(r.URL.Path, "/folders"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `[{"id": "folder-1", "name": "Berita"}]`)
+		case strings.HasSuffix(r.URL.Path, "/playlists"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `[{"id": "pl-1", "name": "Pilihan Redaksi", "video_ids": ["1"]}]`)
+		case strings.HasSuffix(r.URL.Path, "/video_fields"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"custom_fields": [{"id": "author", "type": "string"}], "standard_fields": [{"id": "name"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	dir, err := ioutil.TempDir("", "brighthub-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	manifest, err := BackupAccount(bh, dir)
+	require.NoError(t, err)
+	assert.Equal(t, BackupFormatVersion, manifest.FormatVersion)
+	assert.Equal(t, "acc", manifest.AccountID)
+	assert.Equal(t, 2, manifest.Files[BackupEntityVideos].Count)
+	assert.Equal(t, 1, manifest.Files[BackupEntityFolders].Count)
+	assert.Equal(t, 1, manifest.Files[BackupEntityPlaylists].Count)
+	assert.Equal(t, 1, manifest.Files[BackupEntityCustomFields].Count)
+
+	read, err := ReadBackupManifest(dir)
+	require.NoError(t, err)
+	assert.Equal(t, manifest.Files, read.Files)
+
+	b, err := ioutil.ReadFile(filepath.Join(dir, "videos.jsonl"))
+	require.NoError(t, err)
+	assert.Equal(t, 2, bytes.Count(b, []byte("\n")))
+}
+
+func TestRestoreAccount(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthub-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"),
+		[]byte(`{"format_version": 1, "files": {"videos": {"name": "videos.jsonl", "count": 2}}}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "videos.jsonl"), []byte(
+		`{"id": "1", "name": "Video 1", "reference_id": "ref-1", "tags": ["news"], "folder_id": "folder-1"}
+{"id": "2", "name": "Video 2", "reference_id": "ref-2", "description": "restored"}
+`), 0644))
+
+	var mu sync.Mutex
+	var requests []string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path[strings.Index(r.URL.Path, "/videos"):]+" "+string(b))
+		mu.Unlock()
+
+		switch {
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/1"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "1", "name": "Video 1 edited", "reference_id": "ref-1", "tags": ["bad"]}`)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/2"):
+			w.WriteHeader(http.StatusNotFound)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/ref:ref-2"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "20", "name": "Video 2", "reference_id": "ref-2"}`)
+		case r.Method == "PATCH":
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	report, err := RestoreAccount(bh, dir, &RestoreOptions{DryRun: true})
+	require.NoError(t, err)
+	require.Equal(t, 2, len(report.Items))
+	assert.Equal(t, []string{"name", "tags", "folder_id"}, report.Items[0].Changed)
+	assert.Equal(t, "20", report.Items[1].VideoID)
+	assert.Equal(t, []string{"description"}, report.Items[1].Changed)
+	for _, r := range requests {
+		assert.True(t, strings.HasPrefix(r, "GET"), r)
+	}
+
+	requests = nil
+	report, err = RestoreAccount(bh, dir, &RestoreOptions{VideoIDs: []string{"ref-1"}, Fields: []string{"tags"}})
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Items))
+	assert.NoError(t, report.Items[0].Err)
+	assert.Equal(t, []string{"tags"}, report.Items[0].Changed)
+	require.Equal(t, 2, len(requests))
+	assert.Equal(t, "PATCH /videos/1 {\"tags\":[\"news\"]}\n", requests[1])
+
+	var buf bytes.Buffer
+	assert.NoError(t, report.Write(&buf))
+	assert.Contains(t, buf.String(), "tags")
+}
+
+func TestRestoreAccount_RemoveAddedMetadata(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthub-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"),
+		[]byte(`{"format_version": 1, "files": {"videos": {"name": "videos.jsonl", "count": 1}}}`), 0644))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "videos.jsonl"), []byte(
+		`{"id": "1", "name": "Video 1", "reference_id": "ref-1", "custom_fields": {"author": "Ahmad"}}
+`), 0644))
+
+	var patch string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			// geo, schedule, tags, cue points and the editor custom field are added after the backup
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "1", "name": "Video 1", "reference_id": "ref-1", "tags": ["liga-1"],
+				"custom_fields": {"author": "Ahmad", "editor": "Budi"},
+				"cue_points": [{"id": "cp-1", "name": "ad", "type": "AD", "time": 10}],
+				"schedule": {"starts_at": "2020-01-01T00:00:00.000Z", "ends_at": null},
+				"geo": {"countries": ["id"], "exclude_countries": false, "restricted": true}}`)
+		case "PATCH":
+			b, _ := ioutil.ReadAll(r.Body)
+			patch = string(b)
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+		}
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	report, err := RestoreAccount(bh, dir, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(report.Items))
+	assert.NoError(t, report.Items[0].Err)
+	assert.Equal(t, []string{"tags", "custom_fields", "cue_points", "schedule", "geo"}, report.Items[0].Changed)
+	assert.JSONEq(t, `{
+		"tags": [],
+		"custom_fields": {"editor": ""},
+		"cue_points": [],
+		"schedule": {"starts_at": null, "ends_at": null},
+		"geo": {"countries": null, "exclude_countries": false, "restricted": false}
+	}`, patch)
+}
+
+func TestReadBackupManifest_UnsupportedVersion(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthub-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "manifest.json"), []byte(`{"format_version": 2}`), 0644))
+
+	_, err = ReadBackupManifest(dir)
+	assert.Equal(t, ErrUnsupportedBackupVersion, err)
+}
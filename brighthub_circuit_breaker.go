@@ -0,0 +1,228 @@
+package brighthub
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+type (
+	// Option :nodoc:
+	Option func(c *client)
+
+	// CircuitState :nodoc:
+	CircuitState string
+
+	// CircuitBreakerConfig circuit breaker of each API family, requests fail fast with ErrCircuitOpen while
+	// the circuit is open. Only 5xx responses and timeouts are failures.
+	CircuitBreakerConfig struct {
+		// FailureRatio open the circuit when the failed requests ratio in Window reach it, default to 0.5
+		FailureRatio float64
+		// MinRequests requests in Window before FailureRatio is considered, default to 10
+		MinRequests int
+		// Window how long requests are counted while the circuit is closed, default to 1 minute
+		Window time.Duration
+		// OpenTimeout how long the circuit stays open before it is half-open, default to 30 seconds
+		OpenTimeout time.Duration
+		// HalfOpenRequests probe requests allowed while half-open, the circuit is closed when all of them
+		// succeed and opened again on the first failure, default to 1
+		HalfOpenRequests int
+		// OnStateChange is invoked on every state change, it must not block
+		OnStateChange func(api API, from, to CircuitState)
+	}
+
+	circuitBreakers struct {
+		config   CircuitBreakerConfig
+		now      func() time.Time
+		mu       sync.Mutex
+		breakers map[API]*circuitBreaker
+	}
+
+	circuitBreaker struct {
+		state       CircuitState
+		windowStart time.Time
+		requests    int
+		failures    int
+		openedAt    time.Time
+		probes      int
+		successes   int
+	}
+)
+
+const (
+	// CircuitClosed requests are sent
+	CircuitClosed CircuitState = "closed"
+	// CircuitOpen requests fail fast
+	CircuitOpen CircuitState = "open"
+	// CircuitHalfOpen only probe requests are sent
+	CircuitHalfOpen CircuitState = "half_open"
+)
+
+// WithCircuitBreaker enable circuit breaker for the OAuth, CMS, Dynamic Ingest and ingest profiles APIs
+func WithCircuitBreaker(config *CircuitBreakerConfig) Option {
+	return func(c *client) {
+		c.breakers = newCircuitBreakers(config)
+	}
+}
+
+func newCircuitBreakers(config *CircuitBreakerConfig) *circuitBreakers {
+	cb := &circuitBreakers{
+		config:   *config,
+		now:      time.Now,
+		breakers: map[API]*circuitBreaker{},
+	}
+	if cb.config.FailureRatio <= 0 {
+		cb.config.FailureRatio = 0.5
+	}
+	if cb.config.MinRequests <= 0 {
+		cb.config.MinRequests = 10
+	}
+	if cb.config.Window <= 0 {
+		cb.config.Window = time.Minute
+	}
+	if cb.config.OpenTimeout <= 0 {
+		cb.config.OpenTimeout = 30 * time.Second
+	}
+	if cb.config.HalfOpenRequests <= 0 {
+		cb.config.HalfOpenRequests = 1
+	}
+	return cb
+}
+
+// do send the request through the circuit breaker of its API
+func (c *client) do(r *http.Request) (*http.Response, error) {
+	if c.breakers == nil {
+		return c.httpClient.Do(r)
+	}
+
+	api, _ := RequestOperation(r)
+	if api == APIUnknown {
+		return c.httpClient.Do(r)
+	}
+	if !c.breakers.allow(api) {
+		return nil, ErrCircuitOpen
+	}
+
+	resp, err := c.httpClient.Do(r)
+	c.breakers.record(api, isCircuitFailure(r, resp, err))
+	return resp, err
+}
+
+// allow reserve a probe when the circuit is half-open
+func (cb *circuitBreakers) allow(api API) bool {
+	cb.mu.Lock()
+	b := cb.breaker(api)
+	from := b.state
+	if b.state == CircuitOpen && cb.now().Sub(b.openedAt) >= cb.config.OpenTimeout {
+		b.state = CircuitHalfOpen
+		b.probes = 0
+		b.successes = 0
+	}
+
+	allowed := true
+	switch b.state {
+	case CircuitOpen:
+		allowed = false
+	case CircuitHalfOpen:
+		allowed = b.probes < cb.config.HalfOpenRequests
+		if allowed {
+			b.probes++
+		}
+	}
+	to := b.state
+	cb.mu.Unlock()
+
+	cb.notify(api, from, to)
+	return allowed
+}
+
+func (cb *circuitBreakers) record(api API, failed bool) {
+	cb.mu.Lock()
+	b := cb.breaker(api)
+	from := b.state
+	now := cb.now()
+
+	switch b.state {
+	case CircuitHalfOpen:
+		if failed {
+			b.open(now)
+			break
+		}
+		b.successes++
+		if b.successes >= cb.config.HalfOpenRequests {
+			b.close(now)
+		}
+	case CircuitClosed:
+		if now.Sub(b.windowStart) >= cb.config.Window {
+			b.close(now)
+		}
+		b.requests++
+		if failed {
+			b.failures++
+		}
+		if b.requests >= cb.config.MinRequests && float64(b.failures)/float64(b.requests) >= cb.config.FailureRatio {
+			b.open(now)
+		}
+	}
+	to := b.state
+	cb.mu.Unlock()
+
+	cb.notify(api, from, to)
+}
+
+func (cb *circuitBreakers) state(api API) CircuitState {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	return cb.breaker(api).state
+}
+
+func (cb *circuitBreakers) breaker(api API) *circuitBreaker {
+	b, ok := cb.breakers[api]
+	if !ok {
+		b = &circuitBreaker{state: CircuitClosed, windowStart: cb.now()}
+		cb.breakers[api] = b
+	}
+	return b
+}
+
+func (cb *circuitBreakers) notify(api API, from, to CircuitState) {
+	if from != to && cb.config.OnStateChange != nil {
+		cb.config.OnStateChange(api, from, to)
+	}
+}
+
+func (b *circuitBreaker) open(now time.Time) {
+	b.state = CircuitOpen
+	b.openedAt = now
+}
+
+// close start a new window
+func (b *circuitBreaker) close(now time.Time) {
+	b.state = CircuitClosed
+	b.windowStart = now
+	b.requests = 0
+	b.failures = 0
+}
+
+// isCircuitFailure 5xx response or timeout
+func isCircuitFailure(r *http.Request, resp *http.Response, err error) bool {
+	if err == nil {
+		return resp.StatusCode >= http.StatusInternalServerError
+	}
+	if r.Context().Err() == context.DeadlineExceeded {
+		return true
+	}
+	if e, ok := err.(*url.Error); ok {
+		err = e.Err
+		if e.Timeout() {
+			return true
+		}
+	}
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return true
+	}
+	return err == context.DeadlineExceeded
+}
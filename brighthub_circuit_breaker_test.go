@@ -0,0 +1,123 @@
+package brighthub
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type stateChange struct {
+	api      API
+	from, to CircuitState
+}
+
+func newTestCircuitBreakers(config *CircuitBreakerConfig) (*circuitBreakers, *time.Time, *[]stateChange) {
+	now := time.Date(2019, 5, 1, 10, 0, 0, 0, time.UTC)
+	var changes []stateChange
+	config.OnStateChange = func(api API, from, to CircuitState) {
+		changes = append(changes, stateChange{api, from, to})
+	}
+	cb := newCircuitBreakers(config)
+	cb.now = func() time.Time { return now }
+	return cb, &now, &changes
+}
+
+func TestCircuitBreakers(t *testing.T) {
+	cb, now, changes := newTestCircuitBreakers(&CircuitBreakerConfig{MinRequests: 4, OpenTimeout: 10 * time.Second})
+
+	for _, failed := range []bool{false, true, false} {
+		assert.True(t, cb.allow(APICMS))
+		cb.record(APICMS, failed)
+	}
+	assert.Equal(t, CircuitClosed, cb.state(APICMS))
+
+	cb.record(APICMS, true)
+	assert.Equal(t, CircuitOpen, cb.state(APICMS))
+	assert.False(t, cb.allow(APICMS))
+	// every API has its own circuit
+	assert.True(t, cb.allow(APIDynamicIngest))
+
+	*now = now.Add(10 * time.Second)
+	assert.True(t, cb.allow(APICMS))
+	assert.Equal(t, CircuitHalfOpen, cb.state(APICMS))
+	assert.False(t, cb.allow(APICMS), "only a single probe is allowed")
+
+	cb.record(APICMS, true)
+	assert.Equal(t, CircuitOpen, cb.state(APICMS))
+
+	*now = now.Add(10 * time.Second)
+	assert.True(t, cb.allow(APICMS))
+	cb.record(APICMS, false)
+	assert.Equal(t, CircuitClosed, cb.state(APICMS))
+
+	assert.Equal(t, []stateChange{
+		{APICMS, CircuitClosed, CircuitOpen},
+		{APICMS, CircuitOpen, CircuitHalfOpen},
+		{APICMS, CircuitHalfOpen, CircuitOpen},
+		{APICMS, CircuitOpen, CircuitHalfOpen},
+		{APICMS, CircuitHalfOpen, CircuitClosed},
+	}, *changes)
+}
+
+func TestCircuitBreakers_Window(t *testing.T) {
+	cb, now, _ := newTestCircuitBreakers(&CircuitBreakerConfig{MinRequests: 2, Window: time.Minute})
+
+	cb.record(APIOAuth, true)
+	*now = now.Add(time.Minute)
+	// the failure is from the previous window
+	cb.record(APIOAuth, false)
+	assert.Equal(t, CircuitClosed, cb.state(APIOAuth))
+	cb.record(APIOAuth, true)
+	assert.Equal(t, CircuitOpen, cb.state(APIOAuth))
+}
+
+func TestClient_CircuitBreaker(t *testing.T) {
+	var mu sync.Mutex
+	statusCode := http.StatusInternalServerError
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if statusCode == 0 {
+			time.Sleep(50 * time.Millisecond)
+			return
+		}
+		w.WriteHeader(statusCode)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	var changes []stateChange
+	bh := newClientMock()
+	bh.httpClient = &http.Client{Timeout: 20 * time.Millisecond, Transport: httpMock.Client().Transport}
+	WithCircuitBreaker(&CircuitBreakerConfig{
+		MinRequests: 3,
+		OnStateChange: func(api API, from, to CircuitState) {
+			changes = append(changes, stateChange{api, from, to})
+		},
+	})(bh)
+
+	_, err := bh.GetVideo("1")
+	assert.Equal(t, ErrInternalError, err)
+
+	// too many request doesn't open the circuit
+	mu.Lock()
+	statusCode = http.StatusTooManyRequests
+	mu.Unlock()
+	_, err = bh.GetVideo("1")
+	assert.Equal(t, ErrTooManyRequest, err)
+
+	mu.Lock()
+	statusCode = 0
+	mu.Unlock()
+	_, err = bh.GetVideo("1")
+	assert.Error(t, err)
+
+	_, err = bh.GetVideo("1")
+	assert.Equal(t, ErrCircuitOpen, err)
+	assert.Equal(t, ErrorClassCircuitOpen, ErrorClassOf(err))
+	assert.Equal(t, []stateChange{{APICMS, CircuitClosed, CircuitOpen}}, changes)
+}
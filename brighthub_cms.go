@@ -5,6 +5,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 
 	"github.com/kumparan/go-lib/utils"
 	log "github.com/sirupsen/logrus"
@@ -16,12 +19,16 @@ type (
 
 	// CreateVideoRequest :nodoc:
 	CreateVideoRequest struct {
-		Name            string   `json:"name"`
-		Description     string   `json:"description"`
-		LongDescription string   `json:"long_description"`
-		ReferenceID     string   `json:"reference_id,omitempty"`
-		State           State    `json:"state"`
-		Tags            []string `json:"tags,omitempty"`
+		Name            string            `json:"name"`
+		Description     string            `json:"description"`
+		LongDescription string            `json:"long_description"`
+		ReferenceID     string            `json:"reference_id,omitempty"`
+		State           State             `json:"state"`
+		Tags            []string          `json:"tags,omitempty"`
+		CustomFields    map[string]string `json:"custom_fields,omitempty"`
+		CuePoints       []*CuePoint       `json:"cue_points,omitempty"`
+		Schedule        *VideoSchedule    `json:"schedule,omitempty"`
+		Geo             *VideoGeo         `json:"geo,omitempty"`
 		// TODO Add more request body
 		// to richest create video request
 	}
@@ -33,16 +40,122 @@ type (
 		// TODO add more response field
 	}
 
+	// UpdateVideoRequest only non empty fields are updated
+	UpdateVideoRequest struct {
+		Name            string  `json:"name,omitempty"`
+		Description     *string `json:"description,omitempty"`
+		LongDescription *string `json:"long_description,omitempty"`
+		ReferenceID     string  `json:"reference_id,omitempty"`
+		State           State   `json:"state,omitempty"`
+		// Tags replace every tag when not nil, set to empty slice to remove them all
+		Tags []string `json:"tags,omitempty"`
+		// CustomFields set a field to empty string to remove it, fields not in the map are kept as is
+		CustomFields map[string]string `json:"custom_fields,omitempty"`
+		// CuePoints replace every cue point when not nil, set to empty slice to remove them all
+		CuePoints []*CuePoint `json:"cue_points,omitempty"`
+		// Schedule set both StartsAt and EndsAt to nil to remove the schedule
+		Schedule *VideoSchedule `json:"schedule,omitempty"`
+		// Geo set Restricted to false to remove the restriction
+		Geo *VideoGeo `json:"geo,omitempty"`
+	}
+
+	// VideoQuery CMS video search, see Brightcove CMS search syntax for Query
+	VideoQuery struct {
+		Query  string
+		Sort   string
+		Limit  int
+		Offset int
+	}
+
+	// Video :nodoc:
+	Video struct {
+		ID              string            `json:"id"`
+		AccountID       string            `json:"account_id"`
+		Name            string            `json:"name"`
+		Description     string            `json:"description"`
+		LongDescription string            `json:"long_description"`
+		ReferenceID     string            `json:"reference_id"`
+		State           State             `json:"state"`
+		Tags            []string          `json:"tags"`
+		CustomFields    map[string]string `json:"custom_fields"`
+		FolderID        string            `json:"folder_id"`
+		DeliveryType    string            `json:"delivery_type"`
+		Duration        Duration          `json:"duration"`
+		TextTracks      []*TextTrack      `json:"text_tracks"`
+		CuePoints       []*CuePoint       `json:"cue_points"`
+		Schedule        *VideoSchedule    `json:"schedule"`
+		Geo             *VideoGeo         `json:"geo"`
+		CreatedAt       Timestamp         `json:"created_at"`
+		UpdatedAt       Timestamp         `json:"updated_at"`
+		PublishedAt     Timestamp         `json:"published_at"`
+	}
+
 	// VideoMasterInfo :nodoc:
 	VideoMasterInfo struct {
-		EncodingRate int64  `json:"encoding_rate"`
-		Height       int64  `json:"height"`
-		Width        int64  `json:"width"`
-		ID           string `json:"id"`
-		Size         int64  `json:"size"`
-		UpdatedAt    string `json:"updated_at"`
-		CreatedAt    string `json:"created_at"`
-		Duration     int64  `json:"duration"`
+		EncodingRate int64     `json:"encoding_rate"`
+		Height       int64     `json:"height"`
+		Width        int64     `json:"width"`
+		ID           string    `json:"id"`
+		Size         int64     `json:"size"`
+		UpdatedAt    Timestamp `json:"updated_at"`
+		CreatedAt    Timestamp `json:"created_at"`
+		Duration     Duration  `json:"duration"`
+	}
+
+	// VideoSource :nodoc:
+	VideoSource struct {
+		AssetID      string                `json:"asset_id,omitempty"`
+		Src          string                `json:"src"`
+		Type         string                `json:"type,omitempty"`
+		Container    string                `json:"container,omitempty"`
+		Codec        string                `json:"codec,omitempty"`
+		Codecs       string                `json:"codecs,omitempty"`
+		EncodingRate int64                 `json:"encoding_rate,omitempty"`
+		AvgBitrate   int64                 `json:"avg_bitrate,omitempty"`
+		Duration     Duration              `json:"duration"`
+		Height       int64                 `json:"height,omitempty"`
+		Width        int64                 `json:"width,omitempty"`
+		Size         int64                 `json:"size,omitempty"`
+		Profiles     string                `json:"profiles,omitempty"`
+		KeySystems   map[string]*KeySystem `json:"key_systems,omitempty"`
+	}
+
+	// KeySystem DRM key system of a source
+	KeySystem struct {
+		LicenseURL     string `json:"license_url,omitempty"`
+		CertificateURL string `json:"certificate_url,omitempty"`
+	}
+
+	// VideoRendition :nodoc:
+	VideoRendition struct {
+		ID             string    `json:"id"`
+		AudioOnly      bool      `json:"audio_only"`
+		EncodingRate   int64     `json:"encoding_rate"`
+		FrameHeight    int64     `json:"frame_height"`
+		FrameWidth     int64     `json:"frame_width"`
+		Size           int64     `json:"size"`
+		RemoteURL      string    `json:"remote_url,omitempty"`
+		Duration       Duration  `json:"duration"`
+		VideoCodec     string    `json:"video_codec,omitempty"`
+		VideoContainer string    `json:"video_container,omitempty"`
+		UpdatedAt      Timestamp `json:"updated_at"`
+		CreatedAt      Timestamp `json:"created_at"`
+	}
+
+	// DynamicRendition rendition of Dynamic Delivery video
+	DynamicRendition struct {
+		RenditionID  string    `json:"rendition_id"`
+		MediaType    string    `json:"media_type"`
+		Codec        string    `json:"codec,omitempty"`
+		EncodingRate int64     `json:"encoding_rate"`
+		FrameHeight  int64     `json:"frame_height,omitempty"`
+		FrameWidth   int64     `json:"frame_width,omitempty"`
+		Size         int64     `json:"size"`
+		Duration     Duration  `json:"duration"`
+		Language     string    `json:"language,omitempty"`
+		Variant      string    `json:"variant,omitempty"`
+		UpdatedAt    Timestamp `json:"updated_at"`
+		CreatedAt    Timestamp `json:"created_at"`
 	}
 )
 
@@ -57,6 +170,12 @@ var cmsBaseURL = "https://cms.api.brightcove.com/v1"
 
 // CreateVideo :nodoc:
 func (c *client) CreateVideo(req *CreateVideoRequest) (*CreateVideoResponse, error) {
+	if req.Geo != nil {
+		if err := req.Geo.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
 	token, err := c.getAccessToken()
 	if err != nil {
 		log.WithFields(log.Fields{
@@ -80,10 +199,11 @@ func (c *client) CreateVideo(req *CreateVideoRequest) (*CreateVideoResponse, err
 			Error(err)
 		return nil, err
 	}
+	r = r.WithContext(c.context())
 	r.Header.Set("Content-Type", "application/json")
 	r.Header.Set("Authorization", "Bearer "+token)
 
-	resp, err := c.httpClient.Do(r)
+	resp, err := c.do(r)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"request": utils.Dump(req)}).
@@ -121,6 +241,203 @@ func (c *client) CreateVideo(req *CreateVideoRequest) (*CreateVideoResponse, err
 	return videoResponse, nil
 }
 
+// GetVideo :nodoc:
+func (c *client) GetVideo(videoID string) (*Video, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/videos/%s", cmsBaseURL, c.accountID, videoID), nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	video := new(Video)
+	err = json.NewDecoder(resp.Body).Decode(&video)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// ListVideos search videos, a single page at most query.Limit videos is returned
+func (c *client) ListVideos(query *VideoQuery) ([]*Video, error) {
+	params := url.Values{}
+	if query.Query != "" {
+		params.Set("q", query.Query)
+	}
+	if query.Sort != "" {
+		params.Set("sort", query.Sort)
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+
+	u := fmt.Sprintf("%s/accounts/%s/videos", cmsBaseURL, c.accountID)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	r, err := c.newRequest("GET", u, nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"query": utils.Dump(query)}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"query": utils.Dump(query)}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return nil, ErrBadRequest
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var videos []*Video
+	err = json.NewDecoder(resp.Body).Decode(&videos)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"query": utils.Dump(query)}).
+			Error(err)
+		return nil, err
+	}
+
+	return videos, nil
+}
+
+// GetVideoByReferenceID :nodoc:
+func (c *client) GetVideoByReferenceID(referenceID string) (*Video, error) {
+	return c.GetVideo("ref:" + url.PathEscape(referenceID))
+}
+
+// MarshalJSON send empty Tags and CuePoints, which omitempty would drop, so they can be removed
+func (r UpdateVideoRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateVideoRequest
+	v := struct {
+		request
+		Tags      *[]string    `json:"tags,omitempty"`
+		CuePoints *[]*CuePoint `json:"cue_points,omitempty"`
+	}{request: request(r)}
+	if r.Tags != nil {
+		v.Tags = &r.Tags
+	}
+	if r.CuePoints != nil {
+		v.CuePoints = &r.CuePoints
+	}
+	return json.Marshal(v)
+}
+
+// UpdateVideo :nodoc:
+func (c *client) UpdateVideo(videoID string, req *UpdateVideoRequest) (*Video, error) {
+	if req.Geo != nil {
+		if err := req.Geo.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	r, err := c.newRequest("PATCH", fmt.Sprintf("%s/accounts/%s/videos/%s", cmsBaseURL, c.accountID, videoID), req)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID,
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID,
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden, http.StatusUnprocessableEntity:
+			return nil, ErrIllegalField
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusMethodNotAllowed:
+			return nil, ErrMethodNotAllowed
+		case http.StatusConflict:
+			return nil, ErrDuplicateReferenceID
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	video := new(Video)
+	err = json.NewDecoder(resp.Body).Decode(&video)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID,
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+
+	return video, nil
+}
+
 // AddVideoToFolder :nodoc:
 func (c *client) AddVideoToFolder(videoID, folderID string) error {
 	token, err := c.getAccessToken()
@@ -140,10 +457,11 @@ func (c *client) AddVideoToFolder(videoID, folderID string) error {
 			Error(err)
 		return err
 	}
+	r = r.WithContext(c.context())
 	r.Header.Set("Content-Type", "application/json")
 	r.Header.Set("Authorization", "Bearer "+token)
 
-	resp, err := c.httpClient.Do(r)
+	resp, err := c.do(r)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"folderID": folderID,
@@ -192,10 +510,11 @@ func (c *client) GetVideoMasterInfo(videoID string) (*VideoMasterInfo, error) {
 			Error(err)
 		return nil, err
 	}
+	r = r.WithContext(c.context())
 	r.Header.Set("Content-Type", "application/json")
 	r.Header.Set("Authorization", "Bearer "+token)
 
-	resp, err := c.httpClient.Do(r)
+	resp, err := c.do(r)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"videoID": videoID}).
@@ -232,3 +551,204 @@ func (c *client) GetVideoMasterInfo(videoID string) (*VideoMasterInfo, error) {
 
 	return videoMasterInfo, nil
 }
+
+// GetVideoSources :nodoc:
+func (c *client) GetVideoSources(videoID string) ([]*VideoSource, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/videos/%s/sources", cmsBaseURL, c.accountID, videoID), nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var sources []*VideoSource
+	err = json.NewDecoder(resp.Body).Decode(&sources)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	return sources, nil
+}
+
+// GetVideoRenditions get renditions of video ingested with legacy ingest profile
+func (c *client) GetVideoRenditions(videoID string) ([]*VideoRendition, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/videos/%s/assets/renditions", cmsBaseURL, c.accountID, videoID), nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var renditions []*VideoRendition
+	err = json.NewDecoder(resp.Body).Decode(&renditions)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	return renditions, nil
+}
+
+// GetVideoDynamicRenditions get renditions of video ingested with Dynamic Delivery profile
+func (c *client) GetVideoDynamicRenditions(videoID string) ([]*DynamicRendition, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/videos/%s/assets/dynamic_renditions", cmsBaseURL, c.accountID, videoID), nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var renditions []*DynamicRendition
+	err = json.NewDecoder(resp.Body).Decode(&renditions)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+
+	return renditions, nil
+}
+
+// HighestMP4Source find the https MP4 source with the highest encoding rate which is not DRM protected,
+// return nil when there is no such source
+func HighestMP4Source(sources []*VideoSource) *VideoSource {
+	var highest *VideoSource
+	for _, s := range sources {
+		if s.Container != "MP4" || len(s.KeySystems) > 0 || !strings.HasPrefix(s.Src, "https://") {
+			continue
+		}
+		if highest == nil || s.EncodingRate > highest.EncodingRate {
+			highest = s
+		}
+	}
+	return highest
+}
+
+// DeleteDigitalMaster delete the archived digital master of a video.
+// Video can't be retranscoded afterward unless the source is ingested again.
+func (c *client) DeleteDigitalMaster(videoID string) error {
+	r, err := c.newRequest("DELETE", fmt.Sprintf("%s/accounts/%s/videos/%s/digital_master", cmsBaseURL, c.accountID, videoID), nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return ErrUnauthorized
+		case http.StatusForbidden:
+			return ErrNotAvailable
+		case http.StatusNotFound:
+			return ErrResourceNotFound
+		case http.StatusMethodNotAllowed:
+			return ErrMethodNotAllowed
+		case http.StatusTooManyRequests:
+			return ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return ErrInternalError
+		default:
+			return fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	return nil
+}
@@ -2,9 +2,11 @@ package brighthub
 
 import (
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/icrowley/fake"
 	"github.com/stretchr/testify/assert"
@@ -78,8 +80,241 @@ func TestClient_GetVideoMasterInfo(t *testing.T) {
 		assert.EqualValues(t, int64(1920), videoMasterInfo.Width)
 		assert.EqualValues(t, "a0a2e032-4de4-4495-a59e-a806d52989", videoMasterInfo.ID)
 		assert.EqualValues(t, int64(90990884), videoMasterInfo.Size)
-		assert.EqualValues(t, "2019-04-30T10:09:12.548Z", videoMasterInfo.CreatedAt)
-		assert.EqualValues(t, "2019-04-30T10:09:12.548Z", videoMasterInfo.UpdatedAt)
-		assert.EqualValues(t, int64(31431), videoMasterInfo.Duration)
+		assert.EqualValues(t, "2019-04-30T10:09:12.548Z", videoMasterInfo.CreatedAt.String())
+		assert.EqualValues(t, "2019-04-30T10:09:12.548Z", videoMasterInfo.UpdatedAt.String())
+		assert.EqualValues(t, int64(31431), videoMasterInfo.Duration.Milliseconds())
+		assert.Equal(t, 31431*time.Millisecond, videoMasterInfo.Duration.Duration)
 	})
 }
+
+func TestClient_GetVideoSources(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/videos/12345/sources")
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[
+			{
+				"src": "https://manifest.prod.boltdns.net/manifest/v1/hls/v4/clear/12345/master.m3u8",
+				"type": "application/x-mpegURL",
+				"ext_x_version": "4"
+			},
+			{
+				"src": "https://manifest.prod.boltdns.net/manifest/v1/dash/live-baseurl/bccenc/12345/manifest.mpd",
+				"type": "application/dash+xml",
+				"key_systems": {
+					"com.widevine.alpha": {"license_url": "https://manifest.prod.boltdns.net/license/v1/cenc/widevine/12345"}
+				}
+			},
+			{
+				"avg_bitrate": 1200000,
+				"width": 960,
+				"height": 540,
+				"size": 4712044,
+				"duration": 31431,
+				"container": "MP4",
+				"codec": "H264",
+				"encoding_rate": 1200000,
+				"src": "https://bcbolt446c5271-a.akamaihd.net/media/v1/pmp4/static/clear/12345/540.mp4"
+			},
+			{
+				"width": 1920,
+				"height": 1080,
+				"container": "MP4",
+				"codec": "H264",
+				"encoding_rate": 4000000,
+				"src": "https://bcbolt446c5271-a.akamaihd.net/media/v1/pmp4/static/clear/12345/1080.mp4"
+			},
+			{
+				"container": "MP4",
+				"codec": "H264",
+				"encoding_rate": 8000000,
+				"src": "http://bcbolt446c5271-a.akamaihd.net/media/v1/pmp4/static/clear/12345/2160.mp4"
+			}
+		]`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	sources, err := bh.GetVideoSources("12345")
+	assert.NoError(t, err)
+	assert.Equal(t, 5, len(sources))
+	assert.Equal(t, "application/x-mpegURL", sources[0].Type)
+	assert.Equal(t, "https://manifest.prod.boltdns.net/license/v1/cenc/widevine/12345", sources[1].KeySystems["com.widevine.alpha"].LicenseURL)
+	assert.Equal(t, "H264", sources[2].Codec)
+	assert.EqualValues(t, 1200000, sources[2].EncodingRate)
+	assert.EqualValues(t, 4712044, sources[2].Size)
+
+	mp4 := HighestMP4Source(sources)
+	assert.Equal(t, sources[3], mp4)
+}
+
+func TestClient_GetVideoRenditions(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/videos/12345/assets/renditions")
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[{
+			"id": "5432",
+			"audio_only": false,
+			"encoding_rate": 1200000,
+			"frame_height": 540,
+			"frame_width": 960,
+			"size": 4712044,
+			"duration": 31431,
+			"video_codec": "H264",
+			"video_container": "MP4",
+			"created_at": "2019-04-30T10:09:12.548Z",
+			"updated_at": "2019-04-30T10:09:12.548Z"
+		}]`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	renditions, err := bh.GetVideoRenditions("12345")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(renditions))
+	assert.Equal(t, "5432", renditions[0].ID)
+	assert.EqualValues(t, 540, renditions[0].FrameHeight)
+	assert.Equal(t, "MP4", renditions[0].VideoContainer)
+}
+
+func TestClient_GetVideoDynamicRenditions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.URL.Path, "/videos/12345/assets/dynamic_renditions")
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `[
+				{"rendition_id": "default/video3500", "media_type": "video", "codec": "avc1.64001f", "encoding_rate": 3500000, "frame_height": 1080, "frame_width": 1920, "size": 13751880, "duration": 31431},
+				{"rendition_id": "default/audio128", "media_type": "audio", "codec": "mp4a.40.2", "encoding_rate": 128000, "size": 503008, "duration": 31431, "language": "id", "variant": "main"}
+			]`)
+		}))
+		defer httpMock.Close()
+		cmsBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		renditions, err := bh.GetVideoDynamicRenditions("12345")
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(renditions))
+		assert.Equal(t, "default/video3500", renditions[0].RenditionID)
+		assert.EqualValues(t, 1920, renditions[0].FrameWidth)
+		assert.Equal(t, "audio", renditions[1].MediaType)
+		assert.Equal(t, "id", renditions[1].Language)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer httpMock.Close()
+		cmsBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		_, err := bh.GetVideoDynamicRenditions("12345")
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+}
+
+func TestClient_DeleteDigitalMaster(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Contains(t, r.URL.Path, "/videos/12345/digital_master")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	err := bh.DeleteDigitalMaster("12345")
+	assert.NoError(t, err)
+}
+
+func TestClient_GetVideo(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/account-id/videos/ref:ref-lucu", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"id": "12345",
+			"name": "video lucu",
+			"reference_id": "ref-lucu",
+			"state": "ACTIVE",
+			"tags": ["kucing"],
+			"custom_fields": {"channel": "kumparanNEWS"},
+			"folder_id": "folder-lucu",
+			"duration": 31431
+		}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.accountID = "account-id"
+	bh.httpClient = httpMock.Client()
+
+	video, err := bh.GetVideoByReferenceID("ref-lucu")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", video.ID)
+	assert.Equal(t, StateActive, video.State)
+	assert.Equal(t, []string{"kucing"}, video.Tags)
+	assert.Equal(t, "kumparanNEWS", video.CustomFields["channel"])
+	assert.Equal(t, "folder-lucu", video.FolderID)
+}
+
+func TestClient_UpdateVideo(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Contains(t, r.URL.Path, "/videos/12345")
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"description": "", "tags": ["kucing"]}`, string(b))
+
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{"id": "12345", "description": "", "tags": ["kucing"]}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	description := ""
+	video, err := bh.UpdateVideo("12345", &UpdateVideoRequest{
+		Description: &description,
+		Tags:        []string{"kucing"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", video.ID)
+}
+
+func TestClient_ListVideos(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "tags:politik", r.URL.Query().Get("q"))
+		assert.Equal(t, "-created_at", r.URL.Query().Get("sort"))
+		assert.Equal(t, "20", r.URL.Query().Get("limit"))
+		assert.Equal(t, "40", r.URL.Query().Get("offset"))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `[{"id": "1"}, {"id": "2"}]`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	videos, err := bh.ListVideos(&VideoQuery{Query: "tags:politik", Sort: "-created_at", Limit: 20, Offset: 40})
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(videos))
+}
@@ -0,0 +1,203 @@
+package brighthub
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// CuePointType :nodoc:
+	CuePointType string
+
+	// CuePoint :nodoc:
+	CuePoint struct {
+		ID   string       `json:"id,omitempty"`
+		Name string       `json:"name"`
+		Type CuePointType `json:"type"`
+		// Time in seconds from the video start
+		Time      float64 `json:"time"`
+		Metadata  string  `json:"metadata,omitempty"`
+		ForceStop bool    `json:"force_stop"`
+	}
+
+	// replaceCuePointsRequest cue_points is always sent, so empty slice remove every cue point
+	replaceCuePointsRequest struct {
+		CuePoints []*CuePoint `json:"cue_points"`
+	}
+
+	// chapterJSON time is either seconds or WebVTT timestamp (hh:mm:ss.ttt)
+	chapterJSON struct {
+		Name     string          `json:"name"`
+		Time     json.RawMessage `json:"time"`
+		Metadata string          `json:"metadata"`
+	}
+)
+
+const (
+	// CuePointTypeAd ad marker
+	CuePointTypeAd CuePointType = "AD"
+	// CuePointTypeCode chapter or other marker handled by the player
+	CuePointTypeCode CuePointType = "CODE"
+)
+
+// ReplaceCuePoints replace every cue point of the video, pass empty cuePoints to remove them all
+func (c *client) ReplaceCuePoints(videoID string, cuePoints []*CuePoint) (*Video, error) {
+	if cuePoints == nil {
+		cuePoints = []*CuePoint{}
+	}
+	req := &replaceCuePointsRequest{CuePoints: cuePoints}
+
+	r, err := c.newRequest("PATCH", fmt.Sprintf("%s/accounts/%s/videos/%s", cmsBaseURL, c.accountID, videoID), req)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID":   videoID,
+			"cuePoints": utils.Dump(cuePoints)}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID":   videoID,
+			"cuePoints": utils.Dump(cuePoints)}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden, http.StatusUnprocessableEntity:
+			return nil, ErrIllegalField
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	video := new(Video)
+	err = json.NewDecoder(resp.Body).Decode(&video)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID":   videoID,
+			"cuePoints": utils.Dump(cuePoints)}).
+			Error(err)
+		return nil, err
+	}
+
+	return video, nil
+}
+
+// ParseWebVTTChapters parse WebVTT chapters file into CODE cue points,
+// the cue text becomes the name and the cue start time becomes the time
+func ParseWebVTTChapters(r io.Reader) ([]*CuePoint, error) {
+	scanner := bufio.NewScanner(r)
+	if !scanner.Scan() || !strings.HasPrefix(strings.TrimPrefix(scanner.Text(), "\ufeff"), "WEBVTT") {
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+		return nil, ErrInvalidChapters
+	}
+
+	var cuePoints []*CuePoint
+	var current *CuePoint
+	var lines []string
+	flush := func() {
+		if current != nil {
+			current.Name = strings.Join(lines, " ")
+			cuePoints = append(cuePoints, current)
+		}
+		current = nil
+		lines = nil
+	}
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+			flush()
+		case current != nil:
+			lines = append(lines, line)
+		case strings.Contains(line, "-->"):
+			start, err := parseWebVTTTimestamp(strings.TrimSpace(strings.Split(line, "-->")[0]))
+			if err != nil {
+				return nil, err
+			}
+			current = &CuePoint{Type: CuePointTypeCode, Time: start}
+		}
+		// other lines outside a cue are cue identifiers, NOTE, STYLE or REGION blocks
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+
+	return cuePoints, nil
+}
+
+// ParseChapterJSON parse list of chapters into CODE cue points, e.g.
+// [{"name": "Intro", "time": 0}, {"name": "Interview", "time": "00:01:30.500", "metadata": "interview"}]
+func ParseChapterJSON(r io.Reader) ([]*CuePoint, error) {
+	var chapters []*chapterJSON
+	err := json.NewDecoder(r).Decode(&chapters)
+	if err != nil {
+		return nil, err
+	}
+
+	cuePoints := make([]*CuePoint, len(chapters))
+	for i, c := range chapters {
+		var seconds float64
+		if err := json.Unmarshal(c.Time, &seconds); err != nil {
+			var timestamp string
+			if err := json.Unmarshal(c.Time, &timestamp); err != nil {
+				return nil, ErrInvalidChapters
+			}
+			seconds, err = parseWebVTTTimestamp(timestamp)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		cuePoints[i] = &CuePoint{
+			Name:     c.Name,
+			Type:     CuePointTypeCode,
+			Time:     seconds,
+			Metadata: c.Metadata,
+		}
+	}
+	return cuePoints, nil
+}
+
+// parseWebVTTTimestamp parse (hh:)mm:ss(.ttt) into seconds
+func parseWebVTTTimestamp(timestamp string) (float64, error) {
+	parts := strings.Split(timestamp, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return 0, ErrInvalidChapters
+	}
+
+	var seconds float64
+	for _, p := range parts {
+		v, err := strconv.ParseFloat(p, 64)
+		if err != nil || v < 0 {
+			return 0, ErrInvalidChapters
+		}
+		seconds = seconds*60 + v
+	}
+	return seconds, nil
+}
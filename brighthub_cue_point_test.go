@@ -0,0 +1,138 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/icrowley/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_CreateVideo_CuePoints(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(CreateVideoRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Equal(t, 2, len(req.CuePoints))
+		assert.Equal(t, CuePointTypeAd, req.CuePoints[0].Type)
+		assert.True(t, req.CuePoints[0].ForceStop)
+		assert.Equal(t, 30.5, req.CuePoints[1].Time)
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id": "id-video-lucu"}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	_, err := bh.CreateVideo(&CreateVideoRequest{
+		Name:  fake.Title(),
+		State: StateActive,
+		CuePoints: []*CuePoint{
+			{Name: "midroll", Type: CuePointTypeAd, Time: 15, ForceStop: true},
+			{Name: "Bab 2", Type: CuePointTypeCode, Time: 30.5, Metadata: "chapter"},
+		},
+	})
+	assert.NoError(t, err)
+}
+
+func TestClient_ReplaceCuePoints(t *testing.T) {
+	t.Run("Replace", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "PATCH", r.Method)
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"cue_points": [{"name": "midroll", "type": "AD", "time": 15, "force_stop": false}]}`, string(b))
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "12345", "cue_points": [{"id": "cue-1", "name": "midroll", "type": "AD", "time": 15, "force_stop": false}]}`)
+		}))
+		defer httpMock.Close()
+		cmsBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		video, err := bh.ReplaceCuePoints("12345", []*CuePoint{{Name: "midroll", Type: CuePointTypeAd, Time: 15}})
+		assert.NoError(t, err)
+		assert.Equal(t, "cue-1", video.CuePoints[0].ID)
+	})
+
+	t.Run("Remove all", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			b, _ := ioutil.ReadAll(r.Body)
+			assert.JSONEq(t, `{"cue_points": []}`, string(b))
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "12345", "cue_points": []}`)
+		}))
+		defer httpMock.Close()
+		cmsBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		video, err := bh.ReplaceCuePoints("12345", nil)
+		assert.NoError(t, err)
+		assert.Empty(t, video.CuePoints)
+	})
+}
+
+func TestParseWebVTTChapters(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cuePoints, err := ParseWebVTTChapters(strings.NewReader(`WEBVTT - chapters
+
+NOTE dibuat oleh redaksi
+
+chapter-1
+00:00.000 --> 01:30.000
+Pembukaan
+
+chapter-2
+00:01:30.500 --> 00:10:00.000 align:start
+Wawancara
+bagian pertama
+
+01:00:00.000 --> 01:05:00.000
+Penutup
+`))
+		assert.NoError(t, err)
+		assert.Equal(t, []*CuePoint{
+			{Name: "Pembukaan", Type: CuePointTypeCode, Time: 0},
+			{Name: "Wawancara bagian pertama", Type: CuePointTypeCode, Time: 90.5},
+			{Name: "Penutup", Type: CuePointTypeCode, Time: 3600},
+		}, cuePoints)
+	})
+
+	t.Run("Not WebVTT", func(t *testing.T) {
+		_, err := ParseWebVTTChapters(strings.NewReader("1\n00:00:00,000 --> 00:00:01,000\nSRT\n"))
+		assert.Equal(t, ErrInvalidChapters, err)
+	})
+
+	t.Run("Invalid timestamp", func(t *testing.T) {
+		_, err := ParseWebVTTChapters(strings.NewReader("WEBVTT\n\naa:bb --> 00:01.000\nRusak\n"))
+		assert.Equal(t, ErrInvalidChapters, err)
+	})
+}
+
+func TestParseChapterJSON(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		cuePoints, err := ParseChapterJSON(strings.NewReader(`[
+			{"name": "Pembukaan", "time": 0},
+			{"name": "Wawancara", "time": "00:01:30.500", "metadata": "interview"}
+		]`))
+		assert.NoError(t, err)
+		assert.Equal(t, []*CuePoint{
+			{Name: "Pembukaan", Type: CuePointTypeCode, Time: 0},
+			{Name: "Wawancara", Type: CuePointTypeCode, Time: 90.5, Metadata: "interview"},
+		}, cuePoints)
+	})
+
+	t.Run("Invalid time", func(t *testing.T) {
+		_, err := ParseChapterJSON(strings.NewReader(`[{"name": "Pembukaan", "time": true}]`))
+		assert.Equal(t, ErrInvalidChapters, err)
+	})
+}
@@ -0,0 +1,54 @@
+package brighthub
+
+import (
+	"expvar"
+	"strconv"
+)
+
+// ExpvarMetrics Instrumentation which publish request counters as expvar, keyed by "<api>.<operation>":
+//
+//	requests          request count
+//	status            request count by "<api>.<status_code>", 0 when there is no response
+//	errors            failed request count by "<api>.<error_class>"
+//	latency_ms        total latency in milliseconds, divide by requests for the average
+//	retries           total retries
+type ExpvarMetrics struct {
+	requests *expvar.Map
+	status   *expvar.Map
+	errors   *expvar.Map
+	latency  *expvar.Map
+	retries  *expvar.Map
+}
+
+// NewExpvarMetrics publish the metrics under name, it panics when name is already published
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		requests: new(expvar.Map).Init(),
+		status:   new(expvar.Map).Init(),
+		errors:   new(expvar.Map).Init(),
+		latency:  new(expvar.Map).Init(),
+		retries:  new(expvar.Map).Init(),
+	}
+
+	root := expvar.NewMap(name)
+	root.Set("requests", m.requests)
+	root.Set("status", m.status)
+	root.Set("errors", m.errors)
+	root.Set("latency_ms", m.latency)
+	root.Set("retries", m.retries)
+	return m
+}
+
+// ObserveRequest :nodoc:
+func (m *ExpvarMetrics) ObserveRequest(event *RequestEvent) {
+	key := string(event.API) + "." + event.Operation
+	m.requests.Add(key, 1)
+	m.status.Add(string(event.API)+"."+strconv.Itoa(event.StatusCode), 1)
+	if event.ErrorClass != "" {
+		m.errors.Add(string(event.API)+"."+string(event.ErrorClass), 1)
+	}
+	m.latency.AddFloat(key, float64(event.Latency.Nanoseconds())/1e6)
+	if event.Retries > 0 {
+		m.retries.Add(key, int64(event.Retries))
+	}
+}
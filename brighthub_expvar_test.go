@@ -0,0 +1,26 @@
+package brighthub
+
+import (
+	"expvar"
+	"testing"
+	"time"
+
+	"github.com/icrowley/fake"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpvarMetrics(t *testing.T) {
+	// expvar names can't be published twice, even across test runs
+	name := "brighthub_test_" + fake.CharactersN(10)
+	m := NewExpvarMetrics(name)
+	m.ObserveRequest(&RequestEvent{API: APICMS, Operation: "CreateVideo", StatusCode: 201, Latency: 20 * time.Millisecond})
+	m.ObserveRequest(&RequestEvent{API: APICMS, Operation: "CreateVideo", StatusCode: 429,
+		ErrorClass: ErrorClassTooManyRequests, Latency: 10 * time.Millisecond, Retries: 1})
+
+	root := expvar.Get(name).(*expvar.Map)
+	assert.Equal(t, "2", root.Get("requests").(*expvar.Map).Get("cms.CreateVideo").String())
+	assert.Equal(t, "1", root.Get("status").(*expvar.Map).Get("cms.429").String())
+	assert.Equal(t, "1", root.Get("errors").(*expvar.Map).Get("cms.too_many_requests").String())
+	assert.Equal(t, "30", root.Get("latency_ms").(*expvar.Map).Get("cms.CreateVideo").String())
+	assert.Equal(t, "1", root.Get("retries").(*expvar.Map).Get("cms.CreateVideo").String())
+}
@@ -0,0 +1,111 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// Folder CMS folder, a video is in one folder at most
+	Folder struct {
+		ID         string    `json:"id"`
+		AccountID  string    `json:"account_id"`
+		Name       string    `json:"name"`
+		VideoCount int64     `json:"video_count"`
+		CreatedAt  Timestamp `json:"created_at"`
+		UpdatedAt  Timestamp `json:"updated_at"`
+	}
+
+	createFolderRequest struct {
+		Name string `json:"name"`
+	}
+)
+
+// ListFolders :nodoc:
+func (c *client) ListFolders() ([]*Folder, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/folders", cmsBaseURL, c.accountID), nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var folders []*Folder
+	err = json.NewDecoder(resp.Body).Decode(&folders)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return folders, nil
+}
+
+// CreateFolder folder name must be unique in the account
+func (c *client) CreateFolder(name string) (*Folder, error) {
+	r, err := c.newRequest("POST", fmt.Sprintf("%s/accounts/%s/folders", cmsBaseURL, c.accountID), &createFolderRequest{Name: name})
+	if err != nil {
+		log.WithFields(log.Fields{
+			"name": name}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"name": name}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden, http.StatusUnprocessableEntity:
+			return nil, ErrIllegalField
+		case http.StatusConflict:
+			return nil, ErrDuplicateFolderName
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	folder := new(Folder)
+	err = json.NewDecoder(resp.Body).Decode(&folder)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"name": name}).
+			Error(err)
+		return nil, err
+	}
+	return folder, nil
+}
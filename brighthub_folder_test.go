@@ -0,0 +1,55 @@
+package brighthub
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListFolders(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/folders")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `[{"id": "folder-1", "name": "Berita", "video_count": 12, "created_at": "2019-05-01T10:00:00.000Z"}]`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	folders, err := bh.ListFolders()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(folders))
+	assert.Equal(t, "Berita", folders[0].Name)
+	assert.Equal(t, int64(12), folders[0].VideoCount)
+}
+
+func TestClient_CreateFolder(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		if string(b) == "{\"name\":\"Berita\"}\n" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		assert.JSONEq(t, `{"name": "Olahraga"}`, string(b))
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id": "folder-2", "name": "Olahraga"}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	folder, err := bh.CreateFolder("Olahraga")
+	assert.NoError(t, err)
+	assert.Equal(t, "folder-2", folder.ID)
+
+	_, err = bh.CreateFolder("Berita")
+	assert.Equal(t, ErrDuplicateFolderName, err)
+}
@@ -0,0 +1,36 @@
+package brighthub_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/kumparan/brighthub/brighthubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ApplyGeoRestriction_QueryExcludingRestricted(t *testing.T) {
+	brighthub.UseRealBaseURLs()
+	s := brighthubtest.NewServer("account-id", "client-id", "client-secret")
+	defer s.Close()
+	bh, err := s.NewClient()
+	assert.NoError(t, err)
+
+	// more than a page, every updated video drops out of the query
+	for i := 0; i < 250; i++ {
+		s.AddVideo(&brighthub.Video{Name: fmt.Sprintf("Liga 1 #%d", i), Tags: []string{"liga-1"}})
+	}
+	s.AddVideo(&brighthub.Video{Name: "Berita", Tags: []string{"news"}})
+
+	result, err := bh.ApplyGeoRestriction("+tags:liga-1 -geo.restricted:true", &brighthub.VideoGeo{Countries: []string{"id"}, Restricted: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 250, len(result.Updated))
+	assert.Empty(t, result.Failed)
+
+	videos, err := bh.ListVideos(&brighthub.VideoQuery{Query: "+tags:liga-1 -geo.restricted:true"})
+	assert.NoError(t, err)
+	assert.Empty(t, videos)
+	videos, err = bh.ListVideos(&brighthub.VideoQuery{Query: "+geo.restricted:true +geo.countries:id", Limit: 100})
+	assert.NoError(t, err)
+	assert.Equal(t, 100, len(videos))
+}
@@ -0,0 +1,64 @@
+package brighthub
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoGeo_Validate(t *testing.T) {
+	assert.NoError(t, (&VideoGeo{Countries: []string{"id"}, Restricted: true}).Validate())
+	assert.NoError(t, (&VideoGeo{Countries: []string{"ID", "my", "sg"}, Restricted: true}).Validate())
+	assert.NoError(t, (&VideoGeo{}).Validate())
+	assert.Equal(t, ErrInvalidCountryCode, (&VideoGeo{Countries: []string{"idn"}}).Validate())
+	assert.Equal(t, ErrInvalidCountryCode, (&VideoGeo{Countries: []string{"id", "xx"}}).Validate())
+	assert.Equal(t, ErrInvalidCountryCode, (&VideoGeo{Countries: []string{""}}).Validate())
+}
+
+func TestClient_UpdateVideo_InvalidGeo(t *testing.T) {
+	bh := newClientMock()
+
+	_, err := bh.UpdateVideo("12345", &UpdateVideoRequest{Geo: &VideoGeo{Countries: []string{"indonesia"}, Restricted: true}})
+	assert.Equal(t, ErrInvalidCountryCode, err)
+}
+
+func TestClient_ApplyGeoRestriction(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "GET" {
+			assert.Equal(t, "tags:liga-1", r.URL.Query().Get("q"))
+			assert.Equal(t, "created_at", r.URL.Query().Get("sort"))
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `[{"id": "1"}, {"id": "2"}, {"id": "3"}]`)
+			return
+		}
+
+		assert.Equal(t, "PATCH", r.Method)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"geo": {"countries": ["id"], "exclude_countries": false, "restricted": true}}`, string(b))
+		if strings.HasSuffix(r.URL.Path, "/videos/2") {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, fmt.Sprintf(`{"id": "%s"}`, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]))
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	result, err := bh.ApplyGeoRestriction("tags:liga-1", &VideoGeo{Countries: []string{"id"}, Restricted: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"1", "3"}, result.Updated)
+	assert.Equal(t, ErrResourceNotFound, result.Failed["2"])
+
+	_, err = bh.ApplyGeoRestriction("tags:liga-1", &VideoGeo{Countries: []string{"ina"}, Restricted: true})
+	assert.Equal(t, ErrInvalidCountryCode, err)
+}
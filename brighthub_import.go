@@ -0,0 +1,354 @@
+package brighthub
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// ImportFormat :nodoc:
+	ImportFormat string
+
+	// ImportRow video of the import manifest
+	ImportRow struct {
+		// Line line number in the manifest, CSV header is line 1
+		Line         int               `json:"-"`
+		SourceURL    string            `json:"source_url"`
+		Name         string            `json:"name"`
+		Description  string            `json:"description"`
+		Tags         []string          `json:"tags"`
+		ReferenceID  string            `json:"reference_id"`
+		FolderID     string            `json:"folder_id"`
+		CustomFields map[string]string `json:"custom_fields"`
+	}
+
+	// ImportResult result of a row, the row succeeded when Error is empty
+	ImportResult struct {
+		Line        int    `json:"line"`
+		ReferenceID string `json:"reference_id"`
+		VideoID     string `json:"video_id,omitempty"`
+		JobID       string `json:"job_id,omitempty"`
+		Error       string `json:"error,omitempty"`
+		// Pending the ingest job is submitted but the row is not finished, written as soon as the job is submitted
+		// so the resumed run checks the job instead of ingesting again
+		Pending bool `json:"pending,omitempty"`
+		// Skipped the row already succeeded in the previous run
+		Skipped bool `json:"-"`
+	}
+
+	// Importer create, ingest and file videos of the import manifest
+	Importer struct {
+		Client Client
+		// Concurrency how many rows are imported at the same time, default to 4
+		Concurrency int
+		// Profile ingest profile, leave empty to use the account default
+		Profile   string
+		Priority  Priority
+		Callbacks []string
+		// Tag added to every created video, a video already using the row reference id is only resumed
+		// when it has the tag, default to brighthub-import
+		Tag string
+	}
+)
+
+const (
+	// ImportFormatCSV header is required, columns are the ImportRow JSON names,
+	// tags are comma separated and custom fields are custom_fields.<name> columns
+	ImportFormatCSV ImportFormat = "csv"
+	// ImportFormatJSONL an ImportRow JSON object per line
+	ImportFormatJSONL ImportFormat = "jsonl"
+
+	defaultImportConcurrency = 4
+	defaultImportTag         = "brighthub-import"
+	customFieldsColumnPrefix = "custom_fields."
+)
+
+// ReadImportManifest :nodoc:
+func ReadImportManifest(r io.Reader, format ImportFormat) ([]*ImportRow, error) {
+	switch format {
+	case ImportFormatCSV:
+		return readCSVManifest(r)
+	case ImportFormatJSONL:
+		return readJSONLManifest(r)
+	default:
+		return nil, ErrUnsupportedImportFormat
+	}
+}
+
+func readCSVManifest(r io.Reader) ([]*ImportRow, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	header := records[0]
+	rows := make([]*ImportRow, len(records)-1)
+	for i, record := range records[1:] {
+		row := &ImportRow{Line: i + 2}
+		for j, column := range header {
+			value := strings.TrimSpace(record[j])
+			switch column = strings.TrimSpace(column); column {
+			case "source_url":
+				row.SourceURL = value
+			case "name":
+				row.Name = value
+			case "description":
+				row.Description = value
+			case "tags":
+				for _, tag := range strings.Split(value, ",") {
+					if tag = strings.TrimSpace(tag); tag != "" {
+						row.Tags = append(row.Tags, tag)
+					}
+				}
+			case "reference_id":
+				row.ReferenceID = value
+			case "folder_id":
+				row.FolderID = value
+			default:
+				if !strings.HasPrefix(column, customFieldsColumnPrefix) || value == "" {
+					continue
+				}
+				if row.CustomFields == nil {
+					row.CustomFields = map[string]string{}
+				}
+				row.CustomFields[strings.TrimPrefix(column, customFieldsColumnPrefix)] = value
+			}
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+func readJSONLManifest(r io.Reader) ([]*ImportRow, error) {
+	var rows []*ImportRow
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		row := &ImportRow{Line: line}
+		if err := json.Unmarshal(scanner.Bytes(), row); err != nil {
+			return nil, err
+		}
+		rows = append(rows, row)
+	}
+	return rows, scanner.Err()
+}
+
+// ReadImportResults read results file written by Import, to be passed as previous results when resuming
+func ReadImportResults(r io.Reader) ([]*ImportResult, error) {
+	var results []*ImportResult
+	decoder := json.NewDecoder(r)
+	for {
+		result := new(ImportResult)
+		err := decoder.Decode(result)
+		if err == io.EOF {
+			return results, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+}
+
+// Import every row and write the result of each row as a JSON line to w as soon as it finished.
+// Rows which succeeded in previous results are skipped and not written again.
+// Interrupted import is resumed by calling Import with the results written so far, rows whose video
+// was already created are found by their reference id, so every row must have one. Their ingest job
+// recorded in the previous results is checked before ingesting again.
+// Results are in the same order as rows, error is only returned when writing to w failed.
+func (im *Importer) Import(rows []*ImportRow, previous []*ImportResult, w io.Writer) ([]*ImportResult, error) {
+	// the last result of a reference id wins, pending results are followed by the finished one
+	last := map[string]*ImportResult{}
+	for _, p := range previous {
+		last[p.ReferenceID] = p
+	}
+	succeeded := map[string]*ImportResult{}
+	for ref, p := range last {
+		if p.Error == "" && !p.Pending && p.VideoID != "" {
+			succeeded[ref] = p
+		}
+	}
+
+	concurrency := im.Concurrency
+	if concurrency <= 0 {
+		concurrency = defaultImportConcurrency
+	}
+
+	results := make([]*ImportResult, len(rows))
+	encoder := json.NewEncoder(w)
+	var mu sync.Mutex
+	var writeErr error
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for i := 0; i < concurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := im.importRow(rows[i], last[rows[i].ReferenceID], func(pending *ImportResult) {
+					mu.Lock()
+					defer mu.Unlock()
+					if writeErr == nil {
+						writeErr = encoder.Encode(pending)
+					}
+				})
+
+				mu.Lock()
+				results[i] = result
+				if writeErr == nil {
+					writeErr = encoder.Encode(result)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+	for i, row := range rows {
+		if p, ok := succeeded[row.ReferenceID]; ok && row.ReferenceID != "" {
+			result := *p
+			result.Line = row.Line
+			result.Skipped = true
+			results[i] = &result
+			continue
+		}
+
+		mu.Lock()
+		failed := writeErr != nil
+		mu.Unlock()
+		if failed {
+			break
+		}
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	if writeErr != nil {
+		log.Error(writeErr)
+	}
+	return results, writeErr
+}
+
+// importRow previous is the last result of the row reference id, nil when there is none.
+// checkpoint is called with pending result as soon as the ingest job is submitted.
+func (im *Importer) importRow(row *ImportRow, previous *ImportResult, checkpoint func(pending *ImportResult)) *ImportResult {
+	result := &ImportResult{Line: row.Line, ReferenceID: row.ReferenceID}
+	fail := func(err error) *ImportResult {
+		log.WithFields(log.Fields{
+			"line":        row.Line,
+			"referenceID": row.ReferenceID}).
+			Error(err)
+		result.Error = err.Error()
+		return result
+	}
+
+	if row.ReferenceID == "" || row.Name == "" || row.SourceURL == "" {
+		return fail(ErrInvalidImportRow)
+	}
+
+	tag := im.Tag
+	if tag == "" {
+		tag = defaultImportTag
+	}
+	ingested := false
+	created, err := im.Client.CreateVideo(&CreateVideoRequest{
+		Name:         row.Name,
+		Description:  row.Description,
+		ReferenceID:  row.ReferenceID,
+		State:        StateActive,
+		Tags:         append(append([]string{}, row.Tags...), tag),
+		CustomFields: row.CustomFields,
+	})
+	switch {
+	case err == ErrDuplicateReferenceID:
+		video, err := im.Client.GetVideoByReferenceID(row.ReferenceID)
+		if err != nil {
+			return fail(err)
+		}
+		if !containsTag(video.Tags, tag) {
+			// not created by the importer
+			return fail(ErrReferenceIDInUse)
+		}
+		result.VideoID = video.ID
+
+		ingested, err = im.isIngested(video.ID, previous)
+		if err != nil {
+			return fail(err)
+		}
+		if ingested && previous != nil {
+			result.JobID = previous.JobID
+		}
+	case err != nil:
+		return fail(err)
+	default:
+		result.VideoID = created.ID
+	}
+
+	if !ingested {
+		job, err := im.Client.IngestVideo(result.VideoID, &IngestVideoRequest{
+			Master:        &IngestVideoMaster{URL: row.SourceURL},
+			Priority:      im.Priority,
+			CaptureImages: true,
+			Callbacks:     im.Callbacks,
+			Profile:       im.Profile,
+		})
+		if err != nil {
+			return fail(err)
+		}
+		result.JobID = job.ID
+
+		pending := *result
+		pending.Pending = true
+		checkpoint(&pending)
+	}
+
+	if row.FolderID != "" {
+		err = im.Client.AddVideoToFolder(result.VideoID, row.FolderID)
+		if err != nil {
+			return fail(err)
+		}
+	}
+	return result
+}
+
+// isIngested whether the video created by the interrupted run doesn't need another ingest job,
+// the job recorded in previous result is checked, or the video sources when there is no recorded job
+func (im *Importer) isIngested(videoID string, previous *ImportResult) (bool, error) {
+	if previous != nil && previous.VideoID == videoID && previous.JobID != "" {
+		job, err := im.Client.GetIngestJob(videoID, previous.JobID)
+		if err != nil {
+			return false, err
+		}
+		return job.State != IngestJobStateFailed, nil
+	}
+
+	sources, err := im.Client.GetVideoSources(videoID)
+	if err != nil {
+		return false, err
+	}
+	return len(sources) > 0, nil
+}
+
+func containsTag(tags []string, tag string) bool {
+	for _, t := range tags {
+		if t == tag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,150 @@
+package brighthub
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadImportManifest(t *testing.T) {
+	csvManifest := `source_url,name,description,tags,reference_id,folder_id,custom_fields.author
+https://old.host/1.mp4,Banjir Jakarta,banjir,"news, banjir",old-1,folder-1,Ahmad
+https://old.host/2.mp4,Liga 1,,sport,old-2,,
+`
+	rows, err := ReadImportManifest(strings.NewReader(csvManifest), ImportFormatCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, &ImportRow{
+		Line:         2,
+		SourceURL:    "https://old.host/1.mp4",
+		Name:         "Banjir Jakarta",
+		Description:  "banjir",
+		Tags:         []string{"news", "banjir"},
+		ReferenceID:  "old-1",
+		FolderID:     "folder-1",
+		CustomFields: map[string]string{"author": "Ahmad"},
+	}, rows[0])
+	assert.Nil(t, rows[1].CustomFields)
+
+	jsonlManifest := `{"source_url": "https://old.host/1.mp4", "name": "Banjir Jakarta", "tags": ["news"], "reference_id": "old-1", "custom_fields": {"author": "Ahmad"}}
+
+{"source_url": "https://old.host/2.mp4", "name": "Liga 1", "reference_id": "old-2"}
+`
+	rows, err = ReadImportManifest(strings.NewReader(jsonlManifest), ImportFormatJSONL)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(rows))
+	assert.Equal(t, 3, rows[1].Line)
+	assert.Equal(t, "Ahmad", rows[0].CustomFields["author"])
+
+	_, err = ReadImportManifest(strings.NewReader(""), "xml")
+	assert.Equal(t, ErrUnsupportedImportFormat, err)
+}
+
+func TestImporter_Import(t *testing.T) {
+	var mu sync.Mutex
+	var ingested, filed []string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch {
+		case r.Method == "POST" && strings.HasSuffix(r.URL.Path, "/videos"):
+			req := new(CreateVideoRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.Contains(t, req.Tags, "brighthub-import")
+			if req.ReferenceID == "old-2" || req.ReferenceID == "old-5" || req.ReferenceID == "old-6" {
+				// old-2 and old-5 created before the import was interrupted, old-6 is not imported
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id": "video-`+req.ReferenceID+`"}`)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/ref:old-2"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "video-old-2", "tags": ["brighthub-import"]}`)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/video-old-2/sources"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `[{"src": "https://manifest.prod.boltdns.net/master.m3u8"}]`)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/ref:old-5"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "video-old-5", "tags": ["sport", "brighthub-import"]}`)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/video-old-5/ingest_jobs/job-old-5"):
+			// still transcoding, the video has no sources yet
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "job-old-5", "state": "processing"}`)
+		case r.Method == "GET" && strings.HasSuffix(r.URL.Path, "/videos/ref:old-6"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "video-old-6", "tags": ["news"]}`)
+		case strings.HasSuffix(r.URL.Path, "/ingest-requests"):
+			ingested = append(ingested, r.URL.Path)
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "job"}`)
+		case r.Method == "PUT" && strings.Contains(r.URL.Path, "/folders/"):
+			filed = append(filed, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL           // change for test
+	dynamicIngestBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	rows := []*ImportRow{
+		{Line: 1, SourceURL: "https://old.host/1.mp4", Name: "Banjir", ReferenceID: "old-1", FolderID: "folder-1"},
+		{Line: 2, SourceURL: "https://old.host/2.mp4", Name: "Liga 1", ReferenceID: "old-2"},
+		{Line: 3, SourceURL: "https://old.host/3.mp4", Name: "Tanpa Reference ID"},
+		{Line: 4, SourceURL: "https://old.host/4.mp4", Name: "Sudah Diimpor", ReferenceID: "old-4"},
+		{Line: 5, SourceURL: "https://old.host/5.mp4", Name: "Sedang Transcode", ReferenceID: "old-5"},
+		{Line: 6, SourceURL: "https://old.host/6.mp4", Name: "Video Lain", ReferenceID: "old-6"},
+	}
+	previous := []*ImportResult{
+		{Line: 4, ReferenceID: "old-4", VideoID: "video-old-4", JobID: "job", Pending: true},
+		{Line: 4, ReferenceID: "old-4", VideoID: "video-old-4", JobID: "job"},
+		{Line: 1, ReferenceID: "old-1", Error: ErrTooManyRequest.Error()},
+		{Line: 5, ReferenceID: "old-5", VideoID: "video-old-5", JobID: "job-old-5", Pending: true},
+	}
+
+	out := new(bytes.Buffer)
+	im := &Importer{Client: bh, Concurrency: 2, Priority: PriorityLow}
+	results, err := im.Import(rows, previous, out)
+	assert.NoError(t, err)
+
+	assert.Equal(t, "video-old-1", results[0].VideoID)
+	assert.Equal(t, "job", results[0].JobID)
+	assert.Equal(t, "video-old-2", results[1].VideoID)
+	assert.Empty(t, results[1].JobID)
+	assert.Equal(t, ErrInvalidImportRow.Error(), results[2].Error)
+	assert.True(t, results[3].Skipped)
+	assert.Empty(t, results[4].Error)
+	assert.Equal(t, "job-old-5", results[4].JobID)
+	assert.False(t, results[4].Pending)
+	assert.Equal(t, ErrReferenceIDInUse.Error(), results[5].Error)
+
+	assert.Equal(t, []string{"/accounts/" + bh.accountID + "/videos/video-old-1/ingest-requests"}, ingested)
+	assert.Equal(t, 1, len(filed))
+
+	written, err := ReadImportResults(out)
+	assert.NoError(t, err)
+	// the pending result of old-1 is written before its finished result
+	assert.Equal(t, 6, len(written))
+	pending := 0
+	for _, w := range written {
+		if w.Pending {
+			pending++
+			assert.Equal(t, "old-1", w.ReferenceID)
+			assert.Equal(t, "job", w.JobID)
+		}
+	}
+	assert.Equal(t, 1, pending)
+}
@@ -16,17 +16,30 @@ type (
 
 	// IngestVideoRequest :nodoc:
 	IngestVideoRequest struct {
-		Master        *IngestVideoMaster `json:"master"`
-		Priority      Priority           `json:"priority"`
+		// Master leave nil to only add text tracks
+		Master        *IngestVideoMaster `json:"master,omitempty"`
+		Priority      Priority           `json:"priority,omitempty"`
 		CaptureImages bool               `json:"capture-images"`
 		Callbacks     []string           `json:"callbacks,omitempty"`
-		Profile       string             `json:"profile"`
+		Profile       string             `json:"profile,omitempty"`
+		TextTracks    []*IngestTextTrack `json:"text_tracks,omitempty"`
 		// TODO add more request body
 	}
 
+	// IngestTextTrack WebVTT file to be added to the video
+	IngestTextTrack struct {
+		URL     string `json:"url"`
+		Srclang string `json:"srclang"`
+		Kind    string `json:"kind"`
+		Label   string `json:"label,omitempty"`
+		Default bool   `json:"default"`
+	}
+
 	// IngestVideoMaster :nodoc:
 	IngestVideoMaster struct {
-		URL string `json:"url"`
+		URL string `json:"url,omitempty"`
+		// UseArchivedMaster retranscode from the archived digital master instead of URL
+		UseArchivedMaster bool `json:"use_archived_master,omitempty"`
 	}
 
 	// IngestVideoResponse :nodoc:
@@ -35,17 +48,21 @@ type (
 		// TODO add more response body
 	}
 
-	// IngestProfile :nodoc:
-	IngestProfile struct {
-		Name          string        `json:"name"`
-		DisplayName   string        `json:"display_name"`
-		Description   string        `json:"description"`
-		DynamicOrigin DynamicOrigin `json:"dynamic_origin"`
-	}
+	// IngestJobState :nodoc:
+	IngestJobState string
 
-	// DynamicOrigin :nodoc:
-	DynamicOrigin struct {
-		Renditions []string `json:"renditions"`
+	// IngestJob status of ingest job, ErrorCode and ErrorMessage are only set when it failed
+	IngestJob struct {
+		ID             string         `json:"id"`
+		AccountID      string         `json:"account_id"`
+		VideoID        string         `json:"video_id"`
+		State          IngestJobState `json:"state"`
+		Priority       Priority       `json:"priority"`
+		ErrorCode      string         `json:"error_code"`
+		ErrorMessage   string         `json:"error_message"`
+		SubmissionTime Timestamp      `json:"submission_time"`
+		StartedAt      Timestamp      `json:"started_at"`
+		UpdatedAt      Timestamp      `json:"updated_at"`
 	}
 )
 
@@ -54,13 +71,21 @@ const (
 	PriorityLow Priority = "low"
 	// PriorityNormal :nodoc:
 	PriorityNormal Priority = "normal"
-)
 
-var (
-	dynamicIngestBaseURL = "https://ingest.api.brightcove.com/v1"
-	ingestionBaseURL     = "https://ingestion.api.brightcove.com/v1"
+	// IngestJobStateProcessing :nodoc:
+	IngestJobStateProcessing IngestJobState = "processing"
+	// IngestJobStatePublishing :nodoc:
+	IngestJobStatePublishing IngestJobState = "publishing"
+	// IngestJobStatePublished video is playable, but the job may still be processing
+	IngestJobStatePublished IngestJobState = "published"
+	// IngestJobStateFinished :nodoc:
+	IngestJobStateFinished IngestJobState = "finished"
+	// IngestJobStateFailed :nodoc:
+	IngestJobStateFailed IngestJobState = "failed"
 )
 
+var dynamicIngestBaseURL = "https://ingest.api.brightcove.com/v1"
+
 // IngestVideo :nodoc:
 func (c *client) IngestVideo(videoID string, req *IngestVideoRequest) (*IngestVideoResponse, error) {
 	token, err := c.getAccessToken()
@@ -89,10 +114,11 @@ func (c *client) IngestVideo(videoID string, req *IngestVideoRequest) (*IngestVi
 			Error(err)
 		return nil, err
 	}
+	r = r.WithContext(c.context())
 	r.Header.Set("Content-Type", "application/json")
 	r.Header.Set("Authorization", "Bearer "+token)
 
-	resp, err := c.httpClient.Do(r)
+	resp, err := c.do(r)
 	if err != nil {
 		log.WithFields(log.Fields{
 			"videoID": videoID,
@@ -133,25 +159,33 @@ func (c *client) IngestVideo(videoID string, req *IngestVideoRequest) (*IngestVi
 	return ingestResponse, nil
 }
 
-// GetIngestProfile :nodoc:
-func (c *client) GetIngestProfile(id string) (*IngestProfile, error) {
-	token, err := c.getAccessToken()
-	if err != nil {
-		log.WithFields(log.Fields{"profileID": id}).Error(err)
-		return nil, err
-	}
+// Retranscode ingest the video again from its archived digital master using the given profile,
+// leave profile empty to use the account default profile
+func (c *client) Retranscode(videoID, profile string) (*IngestVideoResponse, error) {
+	return c.IngestVideo(videoID, &IngestVideoRequest{
+		Master:   &IngestVideoMaster{UseArchivedMaster: true},
+		Priority: PriorityNormal,
+		Profile:  profile,
+	})
+}
 
-	r, err := http.NewRequest("GET", fmt.Sprintf("%s/accounts/%s/profiles/%s", ingestionBaseURL, c.accountID, id), nil)
+// GetIngestJob :nodoc:
+func (c *client) GetIngestJob(videoID, jobID string) (*IngestJob, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/videos/%s/ingest_jobs/%s", cmsBaseURL, c.accountID, videoID, jobID), nil)
 	if err != nil {
-		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		log.WithFields(log.Fields{
+			"videoID": videoID,
+			"jobID":   jobID}).
+			Error(err)
 		return nil, err
 	}
-	r.Header.Set("Content-Type", "application/json")
-	r.Header.Set("Authorization", "Bearer "+token)
 
-	resp, err := c.httpClient.Do(r)
+	resp, err := c.do(r)
 	if err != nil {
-		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		log.WithFields(log.Fields{
+			"videoID": videoID,
+			"jobID":   jobID}).
+			Error(err)
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -160,25 +194,27 @@ func (c *client) GetIngestProfile(id string) (*IngestProfile, error) {
 		switch resp.StatusCode {
 		case http.StatusUnauthorized:
 			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
 		case http.StatusNotFound:
 			return nil, ErrResourceNotFound
-		case http.StatusConflict:
-			return nil, ErrProfileError
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
 		case http.StatusInternalServerError:
 			return nil, ErrInternalError
-		case http.StatusTooManyRequests:
-			return nil, ErrRateLimitExceeded
 		default:
 			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
 		}
 	}
 
-	ingestProfile := new(IngestProfile)
-	err = json.NewDecoder(resp.Body).Decode(&ingestProfile)
+	job := new(IngestJob)
+	err = json.NewDecoder(resp.Body).Decode(&job)
 	if err != nil {
-		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		log.WithFields(log.Fields{
+			"videoID": videoID,
+			"jobID":   jobID}).
+			Error(err)
 		return nil, err
 	}
-
-	return ingestProfile, nil
+	return job, nil
 }
@@ -1,6 +1,7 @@
 package brighthub
 
 import (
+	"encoding/json"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -32,48 +33,77 @@ func TestClient_IngestVideo(t *testing.T) {
 	assert.Equal(t, "id-video-lucu", resp.ID)
 }
 
-func TestClient_GetIngestProfile(t *testing.T) {
+func TestClient_Retranscode(t *testing.T) {
+	t.Run("Profile", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Contains(t, r.URL.Path, "/videos/id-video-lucu/ingest-requests")
+			req := new(IngestVideoRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.True(t, req.Master.UseArchivedMaster)
+			assert.Empty(t, req.Master.URL)
+			assert.Equal(t, "multi-platform-standard-dynamic", req.Profile)
+
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id": "id-job-lucu"}`)
+		}))
+		defer httpMock.Close()
+		dynamicIngestBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		resp, err := bh.Retranscode("id-video-lucu", "multi-platform-standard-dynamic")
+		assert.NoError(t, err)
+		assert.Equal(t, "id-job-lucu", resp.ID)
+	})
+
+	t.Run("Default profile", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			req := map[string]interface{}{}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.NotContains(t, req, "profile") // account default profile is used
+			assert.Equal(t, "normal", req["priority"])
+
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{"id": "id-job-lucu"}`)
+		}))
+		defer httpMock.Close()
+		dynamicIngestBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		resp, err := bh.Retranscode("id-video-lucu", "")
+		assert.NoError(t, err)
+		assert.Equal(t, "id-job-lucu", resp.ID)
+	})
+}
+
+func TestClient_GetIngestJob(t *testing.T) {
 	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/videos/id-video-lucu/ingest_jobs/id-job-lucu")
 		w.WriteHeader(http.StatusOK)
 		w.Header().Set("Content-Type", "application/json")
 		io.WriteString(w, `{
-			"name": "multi-platform-standard-static",
-			"display_name": "Multiplatform Standard",
-			"description": "Deliver a wide range of content types across a variety of platforms on mobile and desktop.",
-			"dynamic_origin": {
-				"renditions": [
-					"default/audio64",
-					"default/audio128",
-					"default/video700",
-					"default/video2000",
-					"default/video1700",
-					"default/video1200",
-					"default/audio96",
-					"default/video450",
-					"default/video900"
-					]
-			}
+			"id": "id-job-lucu",
+			"video_id": "id-video-lucu",
+			"state": "failed",
+			"error_code": "INVALID_SOURCE",
+			"error_message": "source file is corrupted",
+			"submission_time": "2019-05-01T10:00:00.000Z"
 		}`)
 	}))
 	defer httpMock.Close()
-	ingestionBaseURL = httpMock.URL // change for test
+	cmsBaseURL = httpMock.URL // change for test
 
 	bh := newClientMock()
 	bh.httpClient = httpMock.Client()
 
-	resp, err := bh.GetIngestProfile("id-ingest-profile")
+	job, err := bh.GetIngestJob("id-video-lucu", "id-job-lucu")
 	assert.NoError(t, err)
-	assert.Equal(t, "multi-platform-standard-static", resp.Name)
-	assert.Equal(t, "Multiplatform Standard", resp.DisplayName)
-	assert.Equal(t, "Deliver a wide range of content types across a variety of platforms on mobile and desktop.", resp.Description)
-	assert.Equal(t, 9, len(resp.DynamicOrigin.Renditions))
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/audio64")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/audio128")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video700")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video2000")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video1700")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video1200")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/audio96")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video450")
-	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video900")
+	assert.Equal(t, IngestJobStateFailed, job.State)
+	assert.Equal(t, "source file is corrupted", job.ErrorMessage)
+	assert.Equal(t, "2019-05-01T10:00:00.000Z", job.SubmissionTime.String())
 }
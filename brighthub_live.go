@@ -0,0 +1,362 @@
+package brighthub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// LiveClient client for Brightcove Live API, authorized with API key instead of OAuth
+	LiveClient interface {
+		CreateLiveJob(req *CreateLiveJobRequest) (*LiveJob, error)
+		ListLiveJobs(query *LiveJobQuery) (*LiveJobList, error)
+		GetLiveJob(jobID string) (*LiveJob, error)
+		GetLiveJobEndpoints(jobID string) (*LiveJobEndpoints, error)
+		CancelLiveJob(jobID string) error
+		ActivateSEPJob(jobID string) error
+		DeactivateSEPJob(jobID string) error
+		CreateLiveClips(req *CreateLiveClipsRequest) (*CreateLiveClipsResponse, error)
+	}
+
+	liveClient struct {
+		apiKey     string
+		httpClient *http.Client
+	}
+
+	// LiveJobState :nodoc:
+	LiveJobState string
+
+	// LiveJob :nodoc:
+	LiveJob struct {
+		ID             string          `json:"id"`
+		State          LiveJobState    `json:"state"`
+		Region         string          `json:"region"`
+		LiveStream     bool            `json:"live_stream"`
+		Static         bool            `json:"static"`
+		ChannelType    string          `json:"channel_type"`
+		ReconnectTime  SecondsDuration `json:"reconnect_time"`
+		AdInsertion    bool            `json:"ad_insertion"`
+		StreamURL      string          `json:"stream_url"`
+		StreamName     string          `json:"stream_name"`
+		PlaybackURL    string          `json:"playback_url"`
+		PlaybackURLDVR string          `json:"playback_url_dvr"`
+		Outputs        []*LiveOutput   `json:"outputs"`
+		CreatedAt      UnixTimestamp   `json:"created_at"`
+		UpdatedAt      UnixTimestamp   `json:"updated_at"`
+	}
+
+	// LiveOutput :nodoc:
+	LiveOutput struct {
+		ID               string `json:"id,omitempty"`
+		Label            string `json:"label"`
+		LiveStream       bool   `json:"live_stream"`
+		Height           int64  `json:"height,omitempty"`
+		Width            int64  `json:"width,omitempty"`
+		VideoCodec       string `json:"video_codec,omitempty"`
+		VideoBitrate     int64  `json:"video_bitrate,omitempty"`
+		AudioBitrate     int64  `json:"audio_bitrate,omitempty"`
+		KeyframeInterval int64  `json:"keyframe_interval,omitempty"`
+		SegmentSeconds   int64  `json:"segment_seconds,omitempty"`
+		PlaybackURL      string `json:"playback_url,omitempty"`
+		PlaybackURLDVR   string `json:"playback_url_dvr,omitempty"`
+	}
+
+	// CreateLiveJobRequest set Static to create SEP (static entry point) job
+	CreateLiveJobRequest struct {
+		LiveStream    bool          `json:"live_stream"`
+		Region        string        `json:"region"`
+		ReconnectTime int64         `json:"reconnect_time,omitempty"`
+		Static        bool          `json:"static,omitempty"`
+		ChannelType   string        `json:"channel_type,omitempty"`
+		AdInsertion   bool          `json:"ad_insertion,omitempty"`
+		Outputs       []*LiveOutput `json:"outputs"`
+		Notifications []string      `json:"notifications,omitempty"`
+	}
+
+	// LiveJobQuery :nodoc:
+	LiveJobQuery struct {
+		State      LiveJobState
+		PageSize   int
+		StartToken string
+	}
+
+	// LiveJobList use NextToken as StartToken to get the next page
+	LiveJobList struct {
+		Jobs      []*LiveJob `json:"jobs"`
+		NextToken string     `json:"next_token"`
+	}
+
+	// LiveJobEndpoints where the encoder push the stream to and where it can be played
+	LiveJobEndpoints struct {
+		StreamURL      string
+		StreamName     string
+		PlaybackURL    string
+		PlaybackURLDVR string
+		// OutputPlaybackURLs rendition playback url keyed by output label
+		OutputPlaybackURLs map[string]string
+	}
+
+	// CreateLiveClipsRequest :nodoc:
+	CreateLiveClipsRequest struct {
+		LiveJobID string            `json:"live_job_id"`
+		Outputs   []*LiveClipOutput `json:"outputs"`
+	}
+
+	// LiveClipOutput clip range is either StreamStartTime and StreamEndTime (epoch seconds)
+	// or StartTime and EndTime (seconds relative to the stream start)
+	LiveClipOutput struct {
+		Label           string              `json:"label"`
+		StreamStartTime int64               `json:"stream_start_time,omitempty"`
+		StreamEndTime   int64               `json:"stream_end_time,omitempty"`
+		StartTime       int64               `json:"start_time,omitempty"`
+		EndTime         int64               `json:"end_time,omitempty"`
+		Credentials     string              `json:"credentials,omitempty"`
+		VideoCloud      *LiveClipVideoCloud `json:"videocloud,omitempty"`
+	}
+
+	// LiveClipVideoCloud the Video Cloud video created from the clip
+	LiveClipVideoCloud struct {
+		Video *LiveClipVideo `json:"video"`
+		// Ingest Dynamic Ingest options for the clip, e.g. {"capture-images": true}
+		Ingest map[string]interface{} `json:"ingest,omitempty"`
+	}
+
+	// LiveClipVideo :nodoc:
+	LiveClipVideo struct {
+		Name        string   `json:"name"`
+		Description string   `json:"description,omitempty"`
+		ReferenceID string   `json:"reference_id,omitempty"`
+		Tags        []string `json:"tags,omitempty"`
+	}
+
+	// CreateLiveClipsResponse :nodoc:
+	CreateLiveClipsResponse struct {
+		LiveJobID string         `json:"live_job_id"`
+		VODJobs   []*LiveClipJob `json:"vod_jobs"`
+	}
+
+	// LiveClipJob :nodoc:
+	LiveClipJob struct {
+		ID    string `json:"jvod_id"`
+		Label string `json:"label"`
+	}
+
+	getLiveJobResponse struct {
+		Job *LiveJob `json:"job"`
+	}
+)
+
+const (
+	// LiveJobStateStandby SEP job which is deactivated
+	LiveJobStateStandby LiveJobState = "standby"
+	// LiveJobStateWaiting waiting for the encoder to connect
+	LiveJobStateWaiting LiveJobState = "waiting"
+	// LiveJobStateProcessing :nodoc:
+	LiveJobStateProcessing LiveJobState = "processing"
+	// LiveJobStateDisconnected :nodoc:
+	LiveJobStateDisconnected LiveJobState = "disconnected"
+	// LiveJobStateFinished :nodoc:
+	LiveJobStateFinished LiveJobState = "finished"
+	// LiveJobStateCancelled :nodoc:
+	LiveJobStateCancelled LiveJobState = "cancelled"
+	// LiveJobStateFailed :nodoc:
+	LiveJobStateFailed LiveJobState = "failed"
+)
+
+var liveBaseURL = "https://api.bcovlive.io/v1"
+
+// NewLiveClient :nodoc:
+func NewLiveClient(apiKey string, httpClient *http.Client) LiveClient {
+	c := &liveClient{
+		apiKey:     apiKey,
+		httpClient: httpClient,
+	}
+	if httpClient == nil {
+		c.httpClient = defaultHTTPClient
+	}
+	return c
+}
+
+// CreateLiveJob :nodoc:
+func (c *liveClient) CreateLiveJob(req *CreateLiveJobRequest) (*LiveJob, error) {
+	job := new(LiveJob)
+	err := c.do("POST", "/jobs", req, job)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// ListLiveJobs :nodoc:
+func (c *liveClient) ListLiveJobs(query *LiveJobQuery) (*LiveJobList, error) {
+	params := url.Values{}
+	if query.State != "" {
+		params.Set("state", string(query.State))
+	}
+	if query.PageSize > 0 {
+		params.Set("page_size", strconv.Itoa(query.PageSize))
+	}
+	if query.StartToken != "" {
+		params.Set("start_token", query.StartToken)
+	}
+
+	path := "/jobs"
+	if len(params) > 0 {
+		path += "?" + params.Encode()
+	}
+
+	list := new(LiveJobList)
+	err := c.do("GET", path, nil, list)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"query": utils.Dump(query)}).
+			Error(err)
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetLiveJob :nodoc:
+func (c *liveClient) GetLiveJob(jobID string) (*LiveJob, error) {
+	resp := new(getLiveJobResponse)
+	err := c.do("GET", fmt.Sprintf("/jobs/%s", jobID), nil, resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"jobID": jobID}).
+			Error(err)
+		return nil, err
+	}
+	if resp.Job == nil {
+		return nil, ErrResourceNotFound
+	}
+	return resp.Job, nil
+}
+
+// GetLiveJobEndpoints :nodoc:
+func (c *liveClient) GetLiveJobEndpoints(jobID string) (*LiveJobEndpoints, error) {
+	job, err := c.GetLiveJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := &LiveJobEndpoints{
+		StreamURL:          job.StreamURL,
+		StreamName:         job.StreamName,
+		PlaybackURL:        job.PlaybackURL,
+		PlaybackURLDVR:     job.PlaybackURLDVR,
+		OutputPlaybackURLs: map[string]string{},
+	}
+	for _, o := range job.Outputs {
+		if o.PlaybackURL != "" {
+			endpoints.OutputPlaybackURLs[o.Label] = o.PlaybackURL
+		}
+	}
+	return endpoints, nil
+}
+
+// CancelLiveJob stop the live job, it can't be restarted afterward
+func (c *liveClient) CancelLiveJob(jobID string) error {
+	err := c.do("PUT", fmt.Sprintf("/jobs/%s/cancel", jobID), nil, nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"jobID": jobID}).
+			Error(err)
+		return err
+	}
+	return nil
+}
+
+// ActivateSEPJob activate static entry point job from standby
+func (c *liveClient) ActivateSEPJob(jobID string) error {
+	err := c.do("PUT", fmt.Sprintf("/jobs/%s/activate", jobID), nil, nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"jobID": jobID}).
+			Error(err)
+		return err
+	}
+	return nil
+}
+
+// DeactivateSEPJob put static entry point job to standby, the entry point is kept
+func (c *liveClient) DeactivateSEPJob(jobID string) error {
+	err := c.do("PUT", fmt.Sprintf("/jobs/%s/deactivate", jobID), nil, nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"jobID": jobID}).
+			Error(err)
+		return err
+	}
+	return nil
+}
+
+// CreateLiveClips create VOD clips from live job, each output become a Video Cloud video
+func (c *liveClient) CreateLiveClips(req *CreateLiveClipsRequest) (*CreateLiveClipsResponse, error) {
+	resp := new(CreateLiveClipsResponse)
+	err := c.do("POST", "/vods", req, resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+func (c *liveClient) do(method, path string, body, out interface{}) error {
+	var b io.Reader
+	if body != nil {
+		buf := new(bytes.Buffer)
+		err := json.NewEncoder(buf).Encode(body)
+		if err != nil {
+			return err
+		}
+		b = buf
+	}
+
+	r, err := http.NewRequest(method, liveBaseURL+path, b)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("X-API-KEY", c.apiKey)
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return ErrBadRequest
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ErrUnauthorized
+		case http.StatusNotFound:
+			return ErrResourceNotFound
+		case http.StatusTooManyRequests:
+			return ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return ErrInternalError
+		default:
+			return fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
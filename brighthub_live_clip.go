@@ -0,0 +1,179 @@
+package brighthub
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// LiveClipper cut clips from a live job and file the resulting videos in CMS
+	LiveClipper struct {
+		Live LiveClient
+		CMS  Client
+		// Credentials Live API credentials name of the Video Cloud account, leave empty to use the default
+		Credentials string
+		// PollInterval how often CMS is checked for the clip videos, default to 10 seconds
+		PollInterval time.Duration
+		// Timeout how long to wait for all clip videos, default to 30 minutes
+		Timeout time.Duration
+	}
+
+	// LiveClipRange clip time range relative to the live stream start and the metadata of its video
+	LiveClipRange struct {
+		Start time.Duration
+		End   time.Duration
+		// ReferenceID is used to find the clip video in CMS, generated from the live job and range when empty
+		ReferenceID  string
+		Name         string
+		Description  string
+		Tags         []string
+		CustomFields map[string]string
+		State        State
+		FolderID     string
+	}
+
+	// LiveClipResult either VideoID or Err is set
+	LiveClipResult struct {
+		// Range copy of the requested range, with the generated ReferenceID
+		Range   *LiveClipRange
+		VideoID string
+		Err     error
+	}
+)
+
+const (
+	defaultLiveClipPollInterval = 10 * time.Second
+	defaultLiveClipTimeout      = 30 * time.Minute
+)
+
+// Clip submit every range as VOD clip, wait until each clip exists as CMS video,
+// then apply its metadata and add it to its folder. Waiting stops early when ctx is done,
+// the clips still waiting fail with ctx error. Results are in the same order as ranges.
+func (l *LiveClipper) Clip(ctx context.Context, liveJobID string, ranges []*LiveClipRange) []*LiveClipResult {
+	cms := l.CMS.WithContext(ctx)
+	results := make([]*LiveClipResult, len(ranges))
+	req := &CreateLiveClipsRequest{LiveJobID: liveJobID}
+	var pending []*LiveClipResult
+	for i := range ranges {
+		r := *ranges[i]
+		results[i] = &LiveClipResult{Range: &r}
+		if r.End <= r.Start {
+			results[i].Err = ErrInvalidClipRange
+			continue
+		}
+		if r.ReferenceID == "" {
+			r.ReferenceID = fmt.Sprintf("%s-%d-%d", liveJobID, int64(r.Start.Seconds()), int64(r.End.Seconds()))
+		}
+
+		req.Outputs = append(req.Outputs, &LiveClipOutput{
+			Label:       r.ReferenceID,
+			StartTime:   int64(r.Start.Seconds()),
+			EndTime:     int64(r.End.Seconds()),
+			Credentials: l.Credentials,
+			VideoCloud: &LiveClipVideoCloud{
+				Video: &LiveClipVideo{
+					Name:        r.Name,
+					ReferenceID: r.ReferenceID,
+				},
+			},
+		})
+		pending = append(pending, results[i])
+	}
+	if len(pending) == 0 {
+		return results
+	}
+
+	_, err := l.Live.CreateLiveClips(req)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"liveJobID": liveJobID}).
+			Error(err)
+		for _, p := range pending {
+			p.Err = err
+		}
+		return results
+	}
+
+	pollInterval := l.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultLiveClipPollInterval
+	}
+	timeout := l.Timeout
+	if timeout <= 0 {
+		timeout = defaultLiveClipTimeout
+	}
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		var waiting []*LiveClipResult
+		for _, p := range pending {
+			video, err := cms.GetVideoByReferenceID(p.Range.ReferenceID)
+			switch {
+			case err == ErrResourceNotFound:
+				waiting = append(waiting, p)
+			case err != nil:
+				p.Err = err
+			default:
+				p.VideoID = video.ID
+				p.Err = applyClipMetadata(cms, video.ID, p.Range)
+			}
+		}
+
+		pending = waiting
+		if len(pending) == 0 {
+			return results
+		}
+		select {
+		case <-ctx.Done():
+			for _, p := range pending {
+				p.Err = ctx.Err()
+			}
+			return results
+		case <-timer.C:
+			for _, p := range pending {
+				p.Err = ErrClipTimeout
+			}
+			return results
+		case <-ticker.C:
+		}
+	}
+}
+
+func applyClipMetadata(cms Client, videoID string, r *LiveClipRange) error {
+	req := &UpdateVideoRequest{
+		Tags:         r.Tags,
+		CustomFields: r.CustomFields,
+		State:        r.State,
+	}
+	if r.Description != "" {
+		req.Description = &r.Description
+	}
+
+	_, err := cms.UpdateVideo(videoID, req)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID":     videoID,
+			"referenceID": r.ReferenceID}).
+			Error(err)
+		return err
+	}
+
+	if r.FolderID == "" {
+		return nil
+	}
+	err = cms.AddVideoToFolder(videoID, r.FolderID)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID":  videoID,
+			"folderID": r.FolderID}).
+			Error(err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package brighthub
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveClipper_Clip(t *testing.T) {
+	liveMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := new(CreateLiveClipsRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Equal(t, "job-lucu", req.LiveJobID)
+		assert.Equal(t, 2, len(req.Outputs))
+		assert.EqualValues(t, 60, req.Outputs[0].StartTime)
+		assert.EqualValues(t, 90, req.Outputs[0].EndTime)
+		assert.Equal(t, "job-lucu-60-90", req.Outputs[0].VideoCloud.Video.ReferenceID)
+		assert.Equal(t, "gol-kedua", req.Outputs[1].VideoCloud.Video.ReferenceID)
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"live_job_id": "job-lucu", "vod_jobs": [{"jvod_id": "clip-1"}, {"jvod_id": "clip-2"}]}`)
+	}))
+	defer liveMock.Close()
+	liveBaseURL = liveMock.URL // change for test
+
+	var mu sync.Mutex
+	lookups := map[string]int{}
+	var updated, foldered []string
+	cmsMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "GET" && strings.Contains(r.URL.Path, "/videos/ref:"):
+			ref := strings.TrimPrefix(r.URL.Path[strings.Index(r.URL.Path, "ref:"):], "ref:")
+			lookups[ref]++
+			// the second clip only shows up on the second poll
+			if ref == "gol-kedua" && lookups[ref] < 2 {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"id": "video-%s", "reference_id": "%s"}`, ref, ref)
+		case r.Method == "PATCH":
+			req := new(UpdateVideoRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.Equal(t, []string{"highlight"}, req.Tags)
+			updated = append(updated, r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{}`)
+		case r.Method == "PUT" && strings.Contains(r.URL.Path, "/folders/"):
+			foldered = append(foldered, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer cmsMock.Close()
+	cmsBaseURL = cmsMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = cmsMock.Client()
+	clipper := &LiveClipper{
+		Live:         NewLiveClient("api-key", liveMock.Client()),
+		CMS:          bh,
+		PollInterval: time.Millisecond,
+		Timeout:      time.Second,
+	}
+
+	ranges := []*LiveClipRange{
+		{Start: time.Minute, End: 90 * time.Second, Name: "Gol pertama", Tags: []string{"highlight"}, FolderID: "folder-highlight"},
+		{Start: 2 * time.Minute, End: time.Minute},
+		{Start: 3 * time.Minute, End: 4 * time.Minute, ReferenceID: "gol-kedua", Name: "Gol kedua", Tags: []string{"highlight"}},
+	}
+	results := clipper.Clip(context.Background(), "job-lucu", ranges)
+	assert.Equal(t, 3, len(results))
+	assert.Empty(t, ranges[0].ReferenceID)
+	assert.Equal(t, "job-lucu-60-90", results[0].Range.ReferenceID)
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, "video-job-lucu-60-90", results[0].VideoID)
+	assert.Equal(t, ErrInvalidClipRange, results[1].Err)
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, "video-gol-kedua", results[2].VideoID)
+
+	assert.Equal(t, 2, lookups["gol-kedua"])
+	assert.ElementsMatch(t, []string{"video-job-lucu-60-90", "video-gol-kedua"}, updated)
+	assert.Equal(t, 1, len(foldered))
+	assert.Contains(t, foldered[0], "/folders/folder-highlight/videos/video-job-lucu-60-90")
+}
+
+func TestLiveClipper_Clip_Timeout(t *testing.T) {
+	liveMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"live_job_id": "job-lucu", "vod_jobs": [{"jvod_id": "clip-1"}]}`)
+	}))
+	defer liveMock.Close()
+	liveBaseURL = liveMock.URL // change for test
+
+	cmsMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer cmsMock.Close()
+	cmsBaseURL = cmsMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = cmsMock.Client()
+	clipper := &LiveClipper{
+		Live:         NewLiveClient("api-key", liveMock.Client()),
+		CMS:          bh,
+		PollInterval: time.Millisecond,
+		Timeout:      10 * time.Millisecond,
+	}
+
+	results := clipper.Clip(context.Background(), "job-lucu", []*LiveClipRange{{Start: 0, End: time.Minute}})
+	assert.Equal(t, ErrClipTimeout, results[0].Err)
+	assert.Empty(t, results[0].VideoID)
+
+	t.Run("Canceled", func(t *testing.T) {
+		clipper.Timeout = time.Hour
+		ctx, cancel := context.WithCancel(context.Background())
+		time.AfterFunc(10*time.Millisecond, cancel)
+
+		start := time.Now()
+		results := clipper.Clip(ctx, "job-lucu", []*LiveClipRange{{Start: 0, End: time.Minute}})
+		assert.True(t, time.Since(start) < time.Second)
+		assert.Error(t, results[0].Err)
+		assert.Empty(t, results[0].VideoID)
+	})
+}
@@ -0,0 +1,165 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLiveClient_CreateLiveJob(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/jobs", r.URL.Path)
+		assert.Equal(t, "api-key", r.Header.Get("X-API-KEY"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+
+		req := new(CreateLiveJobRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.True(t, req.LiveStream)
+		assert.True(t, req.Static)
+		assert.Equal(t, "ap-southeast-1", req.Region)
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{
+			"id": "job-lucu",
+			"stream_url": "rtmp://ep1-apse1.bcovlive.io:1935/job-lucu",
+			"stream_name": "alive",
+			"outputs": [{"id": "output-1", "label": "hls720p", "playback_url": "https://bcovlive-a.akamaihd.net/job-lucu/ap-southeast-1/720p.m3u8"}],
+			"playback_url": "https://bcovlive-a.akamaihd.net/job-lucu/ap-southeast-1/playlist.m3u8",
+			"reconnect_time": 1800,
+			"created_at": 1556618952548,
+			"updated_at": 1556618952548
+		}`)
+	}))
+	defer httpMock.Close()
+	liveBaseURL = httpMock.URL // change for test
+
+	lc := NewLiveClient("api-key", httpMock.Client())
+	job, err := lc.CreateLiveJob(&CreateLiveJobRequest{
+		LiveStream: true,
+		Region:     "ap-southeast-1",
+		Static:     true,
+		Outputs:    []*LiveOutput{{Label: "hls720p", LiveStream: true, Height: 720, VideoBitrate: 2400}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "job-lucu", job.ID)
+	assert.Equal(t, "rtmp://ep1-apse1.bcovlive.io:1935/job-lucu", job.StreamURL)
+	assert.Equal(t, "output-1", job.Outputs[0].ID)
+	assert.Equal(t, 30*time.Minute, job.ReconnectTime.Duration)
+	assert.Equal(t, int64(1556618952548), job.CreatedAt.UnixMilliseconds())
+}
+
+func TestLiveClient_ListLiveJobs(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/jobs", r.URL.Path)
+		assert.Equal(t, "standby", r.URL.Query().Get("state"))
+		assert.Equal(t, "10", r.URL.Query().Get("page_size"))
+		assert.Equal(t, "token-1", r.URL.Query().Get("start_token"))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"jobs": [{"id": "job-1", "state": "standby", "static": true}], "next_token": "token-2"}`)
+	}))
+	defer httpMock.Close()
+	liveBaseURL = httpMock.URL // change for test
+
+	lc := NewLiveClient("api-key", httpMock.Client())
+	list, err := lc.ListLiveJobs(&LiveJobQuery{State: LiveJobStateStandby, PageSize: 10, StartToken: "token-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(list.Jobs))
+	assert.Equal(t, LiveJobStateStandby, list.Jobs[0].State)
+	assert.Equal(t, "token-2", list.NextToken)
+}
+
+func TestLiveClient_GetLiveJobEndpoints(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/jobs/job-lucu", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"job": {
+			"id": "job-lucu",
+			"state": "processing",
+			"stream_url": "rtmp://ep1-apse1.bcovlive.io:1935/job-lucu",
+			"stream_name": "alive",
+			"playback_url": "https://bcovlive-a.akamaihd.net/job-lucu/playlist.m3u8",
+			"playback_url_dvr": "https://bcovlive-a.akamaihd.net/job-lucu/playlist_dvr.m3u8",
+			"outputs": [
+				{"label": "hls720p", "playback_url": "https://bcovlive-a.akamaihd.net/job-lucu/720p.m3u8"},
+				{"label": "archive"}
+			]
+		}}`)
+	}))
+	defer httpMock.Close()
+	liveBaseURL = httpMock.URL // change for test
+
+	lc := NewLiveClient("api-key", httpMock.Client())
+	endpoints, err := lc.GetLiveJobEndpoints("job-lucu")
+	assert.NoError(t, err)
+	assert.Equal(t, "alive", endpoints.StreamName)
+	assert.Equal(t, "https://bcovlive-a.akamaihd.net/job-lucu/playlist_dvr.m3u8", endpoints.PlaybackURLDVR)
+	assert.Equal(t, map[string]string{"hls720p": "https://bcovlive-a.akamaihd.net/job-lucu/720p.m3u8"}, endpoints.OutputPlaybackURLs)
+}
+
+func TestLiveClient_ActivateSEPJob(t *testing.T) {
+	var paths []string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		paths = append(paths, r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "job-lucu"}`)
+	}))
+	defer httpMock.Close()
+	liveBaseURL = httpMock.URL // change for test
+
+	lc := NewLiveClient("api-key", httpMock.Client())
+	assert.NoError(t, lc.ActivateSEPJob("job-lucu"))
+	assert.NoError(t, lc.DeactivateSEPJob("job-lucu"))
+	assert.NoError(t, lc.CancelLiveJob("job-lucu"))
+	assert.Equal(t, []string{"/jobs/job-lucu/activate", "/jobs/job-lucu/deactivate", "/jobs/job-lucu/cancel"}, paths)
+}
+
+func TestLiveClient_CreateLiveClips(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "/vods", r.URL.Path)
+			req := new(CreateLiveClipsRequest)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+			assert.Equal(t, "job-lucu", req.LiveJobID)
+			assert.EqualValues(t, 60, req.Outputs[0].StartTime)
+			assert.Equal(t, "Highlight 1", req.Outputs[0].VideoCloud.Video.Name)
+
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"live_job_id": "job-lucu", "vod_jobs": [{"jvod_id": "clip-1", "label": "highlight-1"}]}`)
+		}))
+		defer httpMock.Close()
+		liveBaseURL = httpMock.URL // change for test
+
+		lc := NewLiveClient("api-key", httpMock.Client())
+		resp, err := lc.CreateLiveClips(&CreateLiveClipsRequest{
+			LiveJobID: "job-lucu",
+			Outputs: []*LiveClipOutput{{
+				Label:      "highlight-1",
+				StartTime:  60,
+				EndTime:    120,
+				VideoCloud: &LiveClipVideoCloud{Video: &LiveClipVideo{Name: "Highlight 1"}},
+			}},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, "clip-1", resp.VODJobs[0].ID)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer httpMock.Close()
+		liveBaseURL = httpMock.URL // change for test
+
+		lc := NewLiveClient("api-key", httpMock.Client())
+		_, err := lc.CreateLiveClips(&CreateLiveClipsRequest{LiveJobID: "job-lucu"})
+		assert.Equal(t, ErrUnauthorized, err)
+	})
+}
@@ -0,0 +1,345 @@
+package brighthub
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+type (
+	// API Brightcove API family
+	API string
+
+	// ErrorClass failed request category, matching the errors returned by the clients
+	ErrorClass string
+
+	// RequestEvent a single request sent to Brightcove
+	RequestEvent struct {
+		API API
+		// Operation client method which sent the request, e.g. CreateVideo
+		Operation string
+		Method    string
+		// StatusCode zero when no response is received
+		StatusCode int
+		// ErrorClass empty when the request succeeded
+		ErrorClass ErrorClass
+		Latency    time.Duration
+		// Retries how many times the request was sent before, see WithRetries
+		Retries int
+	}
+
+	// Instrumentation is invoked after every request, it must be safe for concurrent use
+	Instrumentation interface {
+		ObserveRequest(event *RequestEvent)
+	}
+
+	// InstrumentationFunc :nodoc:
+	InstrumentationFunc func(event *RequestEvent)
+
+	instrumentedTransport struct {
+		next            http.RoundTripper
+		instrumentation Instrumentation
+	}
+
+	operationRoute struct {
+		api       API
+		method    string
+		pattern   string
+		operation string
+	}
+
+	retriesKey struct{}
+)
+
+const (
+	// APIOAuth :nodoc:
+	APIOAuth API = "oauth"
+	// APICMS :nodoc:
+	APICMS API = "cms"
+	// APIDynamicIngest :nodoc:
+	APIDynamicIngest API = "dynamic_ingest"
+	// APIIngestProfiles :nodoc:
+	APIIngestProfiles API = "ingest_profiles"
+	// APIAnalytics :nodoc:
+	APIAnalytics API = "analytics"
+	// APIPlayback :nodoc:
+	APIPlayback API = "playback"
+	// APIPlayer :nodoc:
+	APIPlayer API = "player"
+	// APILive :nodoc:
+	APILive API = "live"
+	// APIUnknown request not sent to a known Brightcove API
+	APIUnknown API = "unknown"
+
+	// ErrorClassBadRequest :nodoc:
+	ErrorClassBadRequest ErrorClass = "bad_request"
+	// ErrorClassUnauthorized :nodoc:
+	ErrorClassUnauthorized ErrorClass = "unauthorized"
+	// ErrorClassForbidden :nodoc:
+	ErrorClassForbidden ErrorClass = "forbidden"
+	// ErrorClassNotFound :nodoc:
+	ErrorClassNotFound ErrorClass = "not_found"
+	// ErrorClassConflict :nodoc:
+	ErrorClassConflict ErrorClass = "conflict"
+	// ErrorClassIllegalField :nodoc:
+	ErrorClassIllegalField ErrorClass = "illegal_field"
+	// ErrorClassTooManyRequests :nodoc:
+	ErrorClassTooManyRequests ErrorClass = "too_many_requests"
+	// ErrorClassClientError other 4xx response
+	ErrorClassClientError ErrorClass = "client_error"
+	// ErrorClassInternalError 5xx response
+	ErrorClassInternalError ErrorClass = "internal_error"
+	// ErrorClassTimeout :nodoc:
+	ErrorClassTimeout ErrorClass = "timeout"
+	// ErrorClassNetwork request failed without response
+	ErrorClassNetwork ErrorClass = "network"
+	// ErrorClassCircuitOpen request not sent, see WithCircuitBreaker
+	ErrorClassCircuitOpen ErrorClass = "circuit_open"
+
+	// operationUnknown request which doesn't match any client method
+	operationUnknown = "unknown"
+)
+
+// operationRoutes path patterns are relative to the API base URL, * match a single segment
+var operationRoutes = []*operationRoute{
+	{APIOAuth, "POST", "access_token", "GetAccessToken"},
+
+	{APICMS, "POST", "accounts/*/videos", "CreateVideo"},
+	{APICMS, "GET", "accounts/*/videos", "ListVideos"},
+	{APICMS, "GET", "accounts/*/videos/ref:*", "GetVideoByReferenceID"},
+	{APICMS, "GET", "accounts/*/videos/*", "GetVideo"},
+	{APICMS, "PATCH", "accounts/*/videos/*", "UpdateVideo"},
+	{APICMS, "GET", "accounts/*/videos/*/digital_master", "GetVideoMasterInfo"},
+	{APICMS, "DELETE", "accounts/*/videos/*/digital_master", "DeleteDigitalMaster"},
+	{APICMS, "GET", "accounts/*/videos/*/sources", "GetVideoSources"},
+	{APICMS, "GET", "accounts/*/videos/*/assets/renditions", "GetVideoRenditions"},
+	{APICMS, "GET", "accounts/*/videos/*/assets/dynamic_renditions", "GetVideoDynamicRenditions"},
+	{APICMS, "GET", "accounts/*/videos/*/ingest_jobs/*", "GetIngestJob"},
+	{APICMS, "GET", "accounts/*/folders", "ListFolders"},
+	{APICMS, "POST", "accounts/*/folders", "CreateFolder"},
+	{APICMS, "PUT", "accounts/*/folders/*/videos/*", "AddVideoToFolder"},
+	{APICMS, "GET", "accounts/*/playlists", "ListPlaylists"},
+	{APICMS, "GET", "accounts/*/video_fields", "GetVideoFields"},
+
+	{APIDynamicIngest, "POST", "accounts/*/videos/*/ingest-requests", "IngestVideo"},
+
+	{APIIngestProfiles, "GET", "accounts/*/profiles", "ListIngestProfiles"},
+	{APIIngestProfiles, "POST", "accounts/*/profiles", "CreateIngestProfile"},
+	{APIIngestProfiles, "GET", "accounts/*/profiles/*", "GetIngestProfile"},
+	{APIIngestProfiles, "PUT", "accounts/*/profiles/*", "UpdateIngestProfile"},
+	{APIIngestProfiles, "DELETE", "accounts/*/profiles/*", "DeleteIngestProfile"},
+	{APIIngestProfiles, "GET", "accounts/*/configuration", "GetDefaultIngestProfile"},
+	{APIIngestProfiles, "POST", "accounts/*/configuration", "SetDefaultIngestProfile"},
+	{APIIngestProfiles, "PUT", "accounts/*/configuration", "SetDefaultIngestProfile"},
+
+	{APIAnalytics, "GET", "data", "GetReport"},
+	{APIAnalytics, "GET", "engagement/accounts/*", "GetAccountEngagement"},
+	{APIAnalytics, "GET", "engagement/accounts/*/players/*", "GetPlayerEngagement"},
+	{APIAnalytics, "GET", "engagement/accounts/*/videos/*", "GetVideoEngagement"},
+
+	{APIPlayback, "GET", "accounts/*/videos", "Search"},
+	{APIPlayback, "GET", "accounts/*/videos/ref:*", "GetVideoByReferenceID"},
+	{APIPlayback, "GET", "accounts/*/videos/*", "GetVideo"},
+	{APIPlayback, "GET", "accounts/*/playlists/*", "GetPlaylist"},
+
+	{APIPlayer, "GET", "accounts/*/players", "ListPlayers"},
+	{APIPlayer, "POST", "accounts/*/players", "CreatePlayer"},
+	{APIPlayer, "GET", "accounts/*/players/*", "GetPlayer"},
+	{APIPlayer, "PATCH", "accounts/*/players/*", "UpdatePlayer"},
+	{APIPlayer, "DELETE", "accounts/*/players/*", "DeletePlayer"},
+	{APIPlayer, "GET", "accounts/*/players/*/configuration", "GetPlayerConfiguration"},
+	{APIPlayer, "PATCH", "accounts/*/players/*/configuration", "PatchPlayerConfiguration"},
+	{APIPlayer, "POST", "accounts/*/players/*/publish", "PublishPlayer"},
+	{APIPlayer, "GET", "accounts/*/players/*/embeds", "ListPlayerEmbeds"},
+
+	{APILive, "POST", "jobs", "CreateLiveJob"},
+	{APILive, "GET", "jobs", "ListLiveJobs"},
+	{APILive, "GET", "jobs/*", "GetLiveJob"},
+	{APILive, "PUT", "jobs/*/cancel", "CancelLiveJob"},
+	{APILive, "PUT", "jobs/*/activate", "ActivateSEPJob"},
+	{APILive, "PUT", "jobs/*/deactivate", "DeactivateSEPJob"},
+	{APILive, "POST", "vods", "CreateLiveClips"},
+}
+
+// ObserveRequest :nodoc:
+func (f InstrumentationFunc) ObserveRequest(event *RequestEvent) {
+	f(event)
+}
+
+// NewInstrumentedTransport return transport which report every request to instrumentation, use it as the
+// http.Client transport of any client in this package:
+//
+//	httpClient := &http.Client{Transport: NewInstrumentedTransport(http.DefaultTransport, metrics)}
+//	bh, err := New(clientID, clientSecret, accountID, httpClient)
+func NewInstrumentedTransport(next http.RoundTripper, instrumentation Instrumentation) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &instrumentedTransport{next: next, instrumentation: instrumentation}
+}
+
+// WithRetries let a retrying transport wrapping the instrumented transport report how many times
+// the request was sent before, as the clients don't retry by themselves
+func WithRetries(ctx context.Context, retries int) context.Context {
+	return context.WithValue(ctx, retriesKey{}, retries)
+}
+
+// RoundTrip :nodoc:
+func (t *instrumentedTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	event := &RequestEvent{Method: r.Method}
+	event.API, event.Operation = RequestOperation(r)
+	if retries, ok := r.Context().Value(retriesKey{}).(int); ok {
+		event.Retries = retries
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(r)
+	event.Latency = time.Since(start)
+	if err != nil {
+		event.ErrorClass = transportErrorClass(r, err)
+	} else {
+		event.StatusCode = resp.StatusCode
+		event.ErrorClass = statusErrorClass(resp.StatusCode)
+	}
+
+	t.instrumentation.ObserveRequest(event)
+	return resp, err
+}
+
+// apiOf API family of the url, the base URLs are read on every call as tests change them
+func apiOf(u string) (API, string) {
+	bases := []struct {
+		api  API
+		base string
+	}{
+		{APIOAuth, authBaseURL},
+		{APICMS, cmsBaseURL},
+		{APIDynamicIngest, dynamicIngestBaseURL},
+		{APIIngestProfiles, ingestionBaseURL},
+		{APIAnalytics, analyticsBaseURL},
+		{APIPlayback, playbackAuthBaseURL},
+		{APIPlayback, playbackBaseURL},
+		{APIPlayer, playerBaseURL},
+		{APILive, liveBaseURL},
+	}
+	for _, b := range bases {
+		if strings.HasPrefix(u, b.base+"/") {
+			return b.api, strings.TrimPrefix(u, b.base+"/")
+		}
+	}
+	return APIUnknown, ""
+}
+
+// RequestOperation API family of the request and the client method which sent it
+func RequestOperation(r *http.Request) (API, string) {
+	api, path := apiOf(r.URL.Scheme + "://" + r.URL.Host + r.URL.Path)
+	if api == APIUnknown {
+		return api, operationUnknown
+	}
+
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for _, route := range operationRoutes {
+		if route.api == api && route.method == r.Method && matchSegments(strings.Split(route.pattern, "/"), segments) {
+			return api, route.operation
+		}
+	}
+	return api, operationUnknown
+}
+
+func matchSegments(pattern, segments []string) bool {
+	if len(pattern) != len(segments) {
+		return false
+	}
+	for i, p := range pattern {
+		switch {
+		case p == "*":
+			// reference id lookup has its own route
+			if strings.HasPrefix(segments[i], "ref:") {
+				return false
+			}
+		case strings.HasSuffix(p, "*"):
+			if !strings.HasPrefix(segments[i], strings.TrimSuffix(p, "*")) {
+				return false
+			}
+		case p != segments[i]:
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorClassOf class of the error returned by the clients, empty when it is not a request error
+func ErrorClassOf(err error) ErrorClass {
+	switch err {
+	case nil:
+		return ""
+	case ErrBadRequest:
+		return ErrorClassBadRequest
+	case ErrUnauthorized, ErrInvalidPolicyKey:
+		return ErrorClassUnauthorized
+	case ErrNotAvailable, ErrAccessDenied:
+		return ErrorClassForbidden
+	case ErrResourceNotFound:
+		return ErrorClassNotFound
+	case ErrDuplicateReferenceID, ErrDuplicateFolderName:
+		return ErrorClassConflict
+	case ErrIllegalField:
+		return ErrorClassIllegalField
+	case ErrTooManyRequest, ErrRateLimitExceeded:
+		return ErrorClassTooManyRequests
+	case ErrInternalError:
+		return ErrorClassInternalError
+	case ErrCircuitOpen:
+		return ErrorClassCircuitOpen
+	}
+
+	if e, ok := err.(*url.Error); ok {
+		if e.Timeout() {
+			return ErrorClassTimeout
+		}
+		return ErrorClassNetwork
+	}
+	return ""
+}
+
+func statusErrorClass(statusCode int) ErrorClass {
+	switch {
+	case statusCode < http.StatusBadRequest:
+		return ""
+	case statusCode >= http.StatusInternalServerError:
+		return ErrorClassInternalError
+	}
+
+	switch statusCode {
+	case http.StatusBadRequest:
+		return ErrorClassBadRequest
+	case http.StatusUnauthorized:
+		return ErrorClassUnauthorized
+	case http.StatusForbidden:
+		return ErrorClassForbidden
+	case http.StatusNotFound:
+		return ErrorClassNotFound
+	case http.StatusConflict:
+		return ErrorClassConflict
+	case http.StatusUnprocessableEntity:
+		return ErrorClassIllegalField
+	case http.StatusTooManyRequests:
+		return ErrorClassTooManyRequests
+	default:
+		return ErrorClassClientError
+	}
+}
+
+// transportErrorClass http.Client timeout cancel the request, so the error itself doesn't always tell
+func transportErrorClass(r *http.Request, err error) ErrorClass {
+	if err == context.DeadlineExceeded || r.Context().Err() == context.DeadlineExceeded {
+		return ErrorClassTimeout
+	}
+	if e, ok := err.(net.Error); ok && e.Timeout() {
+		return ErrorClassTimeout
+	}
+	return ErrorClassNetwork
+}
@@ -0,0 +1,117 @@
+package brighthub
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInstrumentedTransport(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/ref:slow"):
+			time.Sleep(50 * time.Millisecond)
+		case strings.HasSuffix(r.URL.Path, "/videos/1"):
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "1"}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	var mu sync.Mutex
+	var events []*RequestEvent
+	bh := newClientMock()
+	bh.httpClient = &http.Client{
+		Timeout: 20 * time.Millisecond,
+		Transport: NewInstrumentedTransport(httpMock.Client().Transport, InstrumentationFunc(func(event *RequestEvent) {
+			mu.Lock()
+			defer mu.Unlock()
+			events = append(events, event)
+		})),
+	}
+
+	_, err := bh.GetVideoByReferenceID("story-1")
+	require.NoError(t, err)
+	_, err = bh.GetVideo("1")
+	assert.Equal(t, ErrTooManyRequest, err)
+	_, err = bh.GetVideoByReferenceID("slow")
+	assert.Error(t, err)
+
+	require.Equal(t, 3, len(events))
+	assert.Equal(t, APICMS, events[0].API)
+	assert.Equal(t, "GetVideoByReferenceID", events[0].Operation)
+	assert.Equal(t, http.StatusOK, events[0].StatusCode)
+	assert.Equal(t, ErrorClass(""), events[0].ErrorClass)
+
+	assert.Equal(t, "GetVideo", events[1].Operation)
+	assert.Equal(t, http.StatusTooManyRequests, events[1].StatusCode)
+	assert.Equal(t, ErrorClassTooManyRequests, events[1].ErrorClass)
+
+	assert.Equal(t, 0, events[2].StatusCode)
+	assert.Equal(t, ErrorClassTimeout, events[2].ErrorClass)
+}
+
+func TestInstrumentedTransport_Retries(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpMock.Close()
+	liveBaseURL = httpMock.URL // change for test
+
+	var event *RequestEvent
+	transport := NewInstrumentedTransport(nil, InstrumentationFunc(func(e *RequestEvent) { event = e }))
+	r, err := http.NewRequest("PUT", httpMock.URL+"/jobs/job-1/cancel", nil)
+	require.NoError(t, err)
+	resp, err := transport.RoundTrip(r.WithContext(WithRetries(context.Background(), 2)))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	assert.Equal(t, APILive, event.API)
+	assert.Equal(t, "CancelLiveJob", event.Operation)
+	assert.Equal(t, 2, event.Retries)
+}
+
+func TestRequestOperation(t *testing.T) {
+	tests := []struct {
+		method    string
+		url       string
+		api       API
+		operation string
+	}{
+		{"POST", authBaseURL + "/access_token?grant_type=client_credentials", APIOAuth, "GetAccessToken"},
+		{"POST", dynamicIngestBaseURL + "/accounts/1/videos/2/ingest-requests", APIDynamicIngest, "IngestVideo"},
+		{"GET", ingestionBaseURL + "/accounts/1/profiles/multi-platform", APIIngestProfiles, "GetIngestProfile"},
+		{"GET", playerBaseURL + "/accounts/1/players/p/embeds", APIPlayer, "ListPlayerEmbeds"},
+		{"GET", playbackBaseURL + "/accounts/1/videos/ref:story", APIPlayback, "GetVideoByReferenceID"},
+		{"GET", analyticsBaseURL + "/data?dimensions=video", APIAnalytics, "GetReport"},
+		{"GET", ingestionBaseURL + "/accounts/1/unknown", APIIngestProfiles, "unknown"},
+		{"GET", "https://kumparan.com/video.mp4", APIUnknown, "unknown"},
+	}
+	for _, tt := range tests {
+		r, err := http.NewRequest(tt.method, tt.url, nil)
+		require.NoError(t, err)
+		api, operation := RequestOperation(r)
+		assert.Equal(t, tt.api, api, tt.url)
+		assert.Equal(t, tt.operation, operation, tt.url)
+	}
+}
+
+func TestErrorClassOf(t *testing.T) {
+	assert.Equal(t, ErrorClass(""), ErrorClassOf(nil))
+	assert.Equal(t, ErrorClass(""), ErrorClassOf(ErrInvalidCountryCode))
+	assert.Equal(t, ErrorClassTooManyRequests, ErrorClassOf(ErrTooManyRequest))
+	assert.Equal(t, ErrorClassNotFound, ErrorClassOf(ErrResourceNotFound))
+	assert.Equal(t, ErrorClassNetwork, ErrorClassOf(&url.Error{Op: "Get", URL: "https://cms.api.brightcove.com", Err: io.EOF}))
+}
@@ -0,0 +1,265 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// PlaybackClient client for Brightcove Playback API, authorized with policy key instead of OAuth
+	PlaybackClient interface {
+		GetVideo(videoID string) (*PlaybackVideo, error)
+		GetVideoByReferenceID(referenceID string) (*PlaybackVideo, error)
+		GetPlaylist(playlistID string) (*PlaybackPlaylist, error)
+		Search(query *PlaybackSearchQuery) (*PlaybackSearchResult, error)
+		WithToken(token string) PlaybackClient
+	}
+
+	playbackClient struct {
+		accountID  string
+		policyKey  string
+		token      string
+		httpClient *http.Client
+	}
+
+	// PlaybackVideo :nodoc:
+	PlaybackVideo struct {
+		ID               string            `json:"id"`
+		AccountID        string            `json:"account_id"`
+		ReferenceID      string            `json:"reference_id"`
+		Name             string            `json:"name"`
+		Description      string            `json:"description"`
+		LongDescription  string            `json:"long_description"`
+		Duration         Duration          `json:"duration"`
+		Tags             []string          `json:"tags"`
+		CustomFields     map[string]string `json:"custom_fields"`
+		Poster           string            `json:"poster"`
+		PosterSources    []*ImageSource    `json:"poster_sources"`
+		Thumbnail        string            `json:"thumbnail"`
+		ThumbnailSources []*ImageSource    `json:"thumbnail_sources"`
+		Sources          []*VideoSource    `json:"sources"`
+		TextTracks       []*TextTrack      `json:"text_tracks"`
+		CuePoints        []*CuePoint       `json:"cue_points"`
+		OfflineEnabled   bool              `json:"offline_enabled"`
+		PublishedAt      Timestamp         `json:"published_at"`
+		CreatedAt        Timestamp         `json:"created_at"`
+		UpdatedAt        Timestamp         `json:"updated_at"`
+	}
+
+	// ImageSource :nodoc:
+	ImageSource struct {
+		Src string `json:"src"`
+	}
+
+	// TextTrack :nodoc:
+	TextTrack struct {
+		ID       string         `json:"id,omitempty"`
+		Src      string         `json:"src"`
+		Srclang  string         `json:"srclang"`
+		Label    string         `json:"label,omitempty"`
+		Kind     string         `json:"kind"`
+		MimeType string         `json:"mime_type,omitempty"`
+		Default  bool           `json:"default"`
+		Sources  []*ImageSource `json:"sources,omitempty"`
+	}
+
+	// PlaybackPlaylist :nodoc:
+	PlaybackPlaylist struct {
+		ID          string           `json:"id"`
+		AccountID   string           `json:"account_id"`
+		ReferenceID string           `json:"reference_id"`
+		Name        string           `json:"name"`
+		Description string           `json:"description"`
+		Type        string           `json:"type"`
+		Videos      []*PlaybackVideo `json:"videos"`
+		CreatedAt   Timestamp        `json:"created_at"`
+		UpdatedAt   Timestamp        `json:"updated_at"`
+	}
+
+	// PlaybackSearchQuery search query, policy key must be search enabled
+	PlaybackSearchQuery struct {
+		Query  string
+		Sort   string
+		Limit  int
+		Offset int
+	}
+
+	// PlaybackSearchResult :nodoc:
+	PlaybackSearchResult struct {
+		Count  int64            `json:"count"`
+		Videos []*PlaybackVideo `json:"videos"`
+	}
+
+	playbackError struct {
+		ErrorCode    string `json:"error_code"`
+		ErrorSubcode string `json:"error_subcode"`
+		Message      string `json:"message"`
+	}
+)
+
+var (
+	playbackBaseURL     = "https://edge.api.brightcove.com/playback/v1"
+	playbackAuthBaseURL = "https://edge-auth.api.brightcove.com/playback/v1"
+)
+
+// NewPlaybackClient :nodoc:
+func NewPlaybackClient(accountID, policyKey string, httpClient *http.Client) PlaybackClient {
+	c := &playbackClient{
+		accountID:  accountID,
+		policyKey:  policyKey,
+		httpClient: httpClient,
+	}
+	if httpClient == nil {
+		c.httpClient = defaultHTTPClient
+	}
+	return c
+}
+
+// WithToken return a copy of the client which send the Playback Restrictions token on every request,
+// see PlaybackTokenSigner to create the token
+func (c *playbackClient) WithToken(token string) PlaybackClient {
+	pc := *c
+	pc.token = token
+	return &pc
+}
+
+// GetVideo :nodoc:
+func (c *playbackClient) GetVideo(videoID string) (*PlaybackVideo, error) {
+	video := new(PlaybackVideo)
+	err := c.get(fmt.Sprintf("/videos/%s", url.PathEscape(videoID)), nil, video)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": videoID}).
+			Error(err)
+		return nil, err
+	}
+	return video, nil
+}
+
+// GetVideoByReferenceID :nodoc:
+func (c *playbackClient) GetVideoByReferenceID(referenceID string) (*PlaybackVideo, error) {
+	video := new(PlaybackVideo)
+	err := c.get(fmt.Sprintf("/videos/ref:%s", url.PathEscape(referenceID)), nil, video)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"referenceID": referenceID}).
+			Error(err)
+		return nil, err
+	}
+	return video, nil
+}
+
+// GetPlaylist :nodoc:
+func (c *playbackClient) GetPlaylist(playlistID string) (*PlaybackPlaylist, error) {
+	playlist := new(PlaybackPlaylist)
+	err := c.get(fmt.Sprintf("/playlists/%s", url.PathEscape(playlistID)), nil, playlist)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playlistID": playlistID}).
+			Error(err)
+		return nil, err
+	}
+	return playlist, nil
+}
+
+// Search :nodoc:
+func (c *playbackClient) Search(query *PlaybackSearchQuery) (*PlaybackSearchResult, error) {
+	params := url.Values{}
+	params.Set("q", query.Query)
+	if query.Sort != "" {
+		params.Set("sort", query.Sort)
+	}
+	if query.Limit > 0 {
+		params.Set("limit", strconv.Itoa(query.Limit))
+	}
+	if query.Offset > 0 {
+		params.Set("offset", strconv.Itoa(query.Offset))
+	}
+
+	result := new(PlaybackSearchResult)
+	err := c.get("/videos", params, result)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"query": query.Query}).
+			Error(err)
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *playbackClient) get(path string, params url.Values, out interface{}) error {
+	baseURL := playbackBaseURL
+	if c.token != "" {
+		baseURL = playbackAuthBaseURL
+	}
+	u := fmt.Sprintf("%s/accounts/%s%s", baseURL, c.accountID, path)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+	r, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return err
+	}
+	if c.policyKey != "" {
+		r.Header.Set("Accept", "application/json;pk="+c.policyKey)
+	}
+	if c.token != "" {
+		r.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return playbackErrorFromResponse(resp)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// playbackErrorFromResponse map Playback API error code, falling back to the response status code
+func playbackErrorFromResponse(resp *http.Response) error {
+	var errs []*playbackError
+	_ = json.NewDecoder(resp.Body).Decode(&errs)
+	if len(errs) > 0 {
+		switch errs[0].ErrorCode {
+		case "VIDEO_NOT_PLAYABLE":
+			return ErrVideoNotPlayable
+		case "ACCESS_DENIED":
+			return ErrAccessDenied
+		case "INVALID_POLICY_KEY":
+			return ErrInvalidPolicyKey
+		case "RESOURCE_NOT_FOUND", "VIDEO_NOT_FOUND", "PLAYLIST_NOT_FOUND":
+			return ErrResourceNotFound
+		case "TOO_MANY_REQUESTS":
+			return ErrTooManyRequest
+		case "SERVER_ERROR":
+			return ErrInternalError
+		}
+	}
+
+	switch resp.StatusCode {
+	case http.StatusBadRequest:
+		return ErrBadRequest
+	case http.StatusUnauthorized:
+		return ErrInvalidPolicyKey
+	case http.StatusForbidden:
+		return ErrAccessDenied
+	case http.StatusNotFound:
+		return ErrResourceNotFound
+	case http.StatusTooManyRequests:
+		return ErrTooManyRequest
+	case http.StatusInternalServerError:
+		return ErrInternalError
+	default:
+		return fmt.Errorf("undefined error with code %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,155 @@
+package brighthub
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPlaybackClient(t *testing.T) {
+	pc := NewPlaybackClient("account-id", "policy-key", nil)
+	assert.NotNil(t, pc)
+
+	pbc := pc.(*playbackClient)
+	assert.Equal(t, "account-id", pbc.accountID)
+	assert.Equal(t, "policy-key", pbc.policyKey)
+	assert.Equal(t, defaultHTTPClient, pbc.httpClient)
+}
+
+func TestPlaybackClient_GetVideo(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/accounts/account-id/videos/12345", r.URL.Path)
+			assert.Equal(t, "application/json;pk=policy-key", r.Header.Get("Accept"))
+			assert.Empty(t, r.Header.Get("Authorization"))
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			io.WriteString(w, `{
+				"id": "12345",
+				"account_id": "account-id",
+				"name": "video lucu",
+				"duration": 31431,
+				"custom_fields": {"channel": "kumparanNEWS"},
+				"poster": "https://cf-images.ap-southeast-1.prod.boltdns.net/poster.jpg",
+				"sources": [
+					{"src": "https://manifest.prod.boltdns.net/master.m3u8", "type": "application/x-mpegURL"},
+					{"src": "https://bcbolt446c5271-a.akamaihd.net/540.mp4", "container": "MP4", "codec": "H264", "encoding_rate": 1200000}
+				],
+				"text_tracks": [
+					{"id": "track-1", "src": "https://cf-images.ap-southeast-1.prod.boltdns.net/id.vtt", "srclang": "id", "label": "Indonesia", "kind": "captions", "mime_type": "text/webvtt", "default": true}
+				]
+			}`)
+		}))
+		defer httpMock.Close()
+		playbackBaseURL = httpMock.URL // change for test
+
+		pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+		video, err := pc.GetVideo("12345")
+		assert.NoError(t, err)
+		assert.Equal(t, "12345", video.ID)
+		assert.EqualValues(t, 31431, video.Duration.Milliseconds())
+		assert.Equal(t, "kumparanNEWS", video.CustomFields["channel"])
+		assert.Equal(t, 2, len(video.Sources))
+		assert.Equal(t, "MP4", video.Sources[1].Container)
+		assert.Equal(t, 1, len(video.TextTracks))
+		assert.Equal(t, "captions", video.TextTracks[0].Kind)
+		assert.True(t, video.TextTracks[0].Default)
+	})
+
+	t.Run("Not Playable", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `[{"error_code": "VIDEO_NOT_PLAYABLE", "message": "This video is not playable"}]`)
+		}))
+		defer httpMock.Close()
+		playbackBaseURL = httpMock.URL // change for test
+
+		pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+		_, err := pc.GetVideo("12345")
+		assert.Equal(t, ErrVideoNotPlayable, err)
+	})
+
+	t.Run("Access Denied", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			io.WriteString(w, `[{"error_code": "ACCESS_DENIED", "error_subcode": "CLIENT_GEO", "message": "Access to this resource is forbidden by access policy."}]`)
+		}))
+		defer httpMock.Close()
+		playbackBaseURL = httpMock.URL // change for test
+
+		pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+		_, err := pc.GetVideo("12345")
+		assert.Equal(t, ErrAccessDenied, err)
+	})
+
+	t.Run("Unknown error code", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer httpMock.Close()
+		playbackBaseURL = httpMock.URL // change for test
+
+		pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+		_, err := pc.GetVideo("12345")
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+}
+
+func TestPlaybackClient_GetVideoByReferenceID(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/account-id/videos/ref:ref-lucu", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "12345", "reference_id": "ref-lucu"}`)
+	}))
+	defer httpMock.Close()
+	playbackBaseURL = httpMock.URL // change for test
+
+	pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+	video, err := pc.GetVideoByReferenceID("ref-lucu")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", video.ID)
+	assert.Equal(t, "ref-lucu", video.ReferenceID)
+}
+
+func TestPlaybackClient_GetPlaylist(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/account-id/playlists/playlist-lucu", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "playlist-lucu", "name": "Playlist Lucu", "type": "EXPLICIT", "videos": [{"id": "1"}, {"id": "2"}]}`)
+	}))
+	defer httpMock.Close()
+	playbackBaseURL = httpMock.URL // change for test
+
+	pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+	playlist, err := pc.GetPlaylist("playlist-lucu")
+	assert.NoError(t, err)
+	assert.Equal(t, "Playlist Lucu", playlist.Name)
+	assert.Equal(t, 2, len(playlist.Videos))
+}
+
+func TestPlaybackClient_Search(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/account-id/videos", r.URL.Path)
+		assert.Equal(t, "tags:politik", r.URL.Query().Get("q"))
+		assert.Equal(t, "-created_at", r.URL.Query().Get("sort"))
+		assert.Equal(t, "10", r.URL.Query().Get("limit"))
+		assert.Empty(t, r.URL.Query().Get("offset"))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"count": 25, "videos": [{"id": "1"}]}`)
+	}))
+	defer httpMock.Close()
+	playbackBaseURL = httpMock.URL // change for test
+
+	pc := NewPlaybackClient("account-id", "policy-key", httpMock.Client())
+	result, err := pc.Search(&PlaybackSearchQuery{
+		Query: "tags:politik",
+		Sort:  "-created_at",
+		Limit: 10,
+	})
+	assert.NoError(t, err)
+	assert.EqualValues(t, 25, result.Count)
+	assert.Equal(t, 1, len(result.Videos))
+}
@@ -0,0 +1,110 @@
+package brighthub
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"time"
+)
+
+type (
+	// PlaybackTokenClaims claims of Playback Restrictions token
+	PlaybackTokenClaims struct {
+		// AccountID (accid) is required
+		AccountID string
+		// ExpiresAt (exp) is required
+		ExpiresAt time.Time
+		NotBefore time.Time
+		// Concurrency (conc) and ConcurrencyLimit (climit) are used for concurrent stream limiting
+		Concurrency      int64
+		ConcurrencyLimit int64
+		UserID           string
+		UserAgent        string
+		ClientIP         string
+		PlaybackRightsID string
+	}
+
+	// PlaybackTokenSigner sign Playback Restrictions token with RS256
+	PlaybackTokenSigner struct {
+		key *rsa.PrivateKey
+	}
+
+	playbackTokenPayload struct {
+		AccountID        string `json:"accid"`
+		ExpiresAt        int64  `json:"exp"`
+		IssuedAt         int64  `json:"iat"`
+		NotBefore        int64  `json:"nbf,omitempty"`
+		Concurrency      int64  `json:"conc,omitempty"`
+		ConcurrencyLimit int64  `json:"climit,omitempty"`
+		UserID           string `json:"uid,omitempty"`
+		UserAgent        string `json:"ua,omitempty"`
+		ClientIP         string `json:"cip,omitempty"`
+		PlaybackRightsID string `json:"prid,omitempty"`
+	}
+)
+
+// playbackTokenHeader is always the same for RS256
+var playbackTokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"RS256","typ":"JWT"}`))
+
+// NewPlaybackTokenSigner create signer from PEM encoded PKCS#1 or PKCS#8 RSA private key
+func NewPlaybackTokenSigner(privateKeyPEM []byte) (*PlaybackTokenSigner, error) {
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, ErrInvalidPrivateKey
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return &PlaybackTokenSigner{key: key}, nil
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, ErrInvalidPrivateKey
+	}
+	rsaKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		return nil, ErrInvalidPrivateKey
+	}
+	return &PlaybackTokenSigner{key: rsaKey}, nil
+}
+
+// Sign :nodoc:
+func (s *PlaybackTokenSigner) Sign(claims *PlaybackTokenClaims) (string, error) {
+	if claims.AccountID == "" || claims.ExpiresAt.IsZero() {
+		return "", ErrInvalidPlaybackClaims
+	}
+
+	payload := &playbackTokenPayload{
+		AccountID:        claims.AccountID,
+		ExpiresAt:        claims.ExpiresAt.Unix(),
+		IssuedAt:         time.Now().Unix(),
+		Concurrency:      claims.Concurrency,
+		ConcurrencyLimit: claims.ConcurrencyLimit,
+		UserID:           claims.UserID,
+		UserAgent:        claims.UserAgent,
+		ClientIP:         claims.ClientIP,
+		PlaybackRightsID: claims.PlaybackRightsID,
+	}
+	if !claims.NotBefore.IsZero() {
+		payload.NotBefore = claims.NotBefore.Unix()
+	}
+
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	signingInput := playbackTokenHeader + "." + base64.RawURLEncoding.EncodeToString(b)
+
+	hash := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
@@ -0,0 +1,138 @@
+package brighthub
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPlaybackTokenSignerMock(t *testing.T) (*PlaybackTokenSigner, *rsa.PrivateKey) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	b, err := x509.MarshalPKCS8PrivateKey(key)
+	assert.NoError(t, err)
+
+	signer, err := NewPlaybackTokenSigner(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: b}))
+	assert.NoError(t, err)
+	return signer, key
+}
+
+func TestNewPlaybackTokenSigner(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	t.Run("PKCS1", func(t *testing.T) {
+		signer, err := NewPlaybackTokenSigner(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+		assert.NoError(t, err)
+		assert.Equal(t, key.N, signer.key.N)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := NewPlaybackTokenSigner([]byte("bukan kunci"))
+		assert.Equal(t, ErrInvalidPrivateKey, err)
+	})
+}
+
+func TestPlaybackTokenSigner_Sign(t *testing.T) {
+	signer, key := newPlaybackTokenSignerMock(t)
+
+	t.Run("Success", func(t *testing.T) {
+		expiresAt := time.Now().Add(time.Hour)
+		token, err := signer.Sign(&PlaybackTokenClaims{
+			AccountID:        "account-id",
+			ExpiresAt:        expiresAt,
+			NotBefore:        expiresAt.Add(-2 * time.Hour),
+			Concurrency:      1,
+			ConcurrencyLimit: 2,
+			UserID:           "user-lucu",
+			UserAgent:        "Mozilla/5.0",
+			ClientIP:         "10.0.0.1",
+			PlaybackRightsID: "premium",
+		})
+		assert.NoError(t, err)
+
+		parts := strings.Split(token, ".")
+		assert.Equal(t, 3, len(parts))
+
+		header, err := base64.RawURLEncoding.DecodeString(parts[0])
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"alg":"RS256","typ":"JWT"}`, string(header))
+
+		b, err := base64.RawURLEncoding.DecodeString(parts[1])
+		assert.NoError(t, err)
+		claims := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(b, &claims))
+		assert.Equal(t, "account-id", claims["accid"])
+		assert.EqualValues(t, expiresAt.Unix(), claims["exp"])
+		assert.EqualValues(t, expiresAt.Add(-2*time.Hour).Unix(), claims["nbf"])
+		assert.EqualValues(t, 1, claims["conc"])
+		assert.EqualValues(t, 2, claims["climit"])
+		assert.Equal(t, "user-lucu", claims["uid"])
+		assert.Equal(t, "Mozilla/5.0", claims["ua"])
+		assert.Equal(t, "10.0.0.1", claims["cip"])
+		assert.Equal(t, "premium", claims["prid"])
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		assert.NoError(t, err)
+		hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], signature))
+	})
+
+	t.Run("Optional claims omitted", func(t *testing.T) {
+		token, err := signer.Sign(&PlaybackTokenClaims{
+			AccountID: "account-id",
+			ExpiresAt: time.Now().Add(time.Hour),
+		})
+		assert.NoError(t, err)
+
+		b, err := base64.RawURLEncoding.DecodeString(strings.Split(token, ".")[1])
+		assert.NoError(t, err)
+		claims := map[string]interface{}{}
+		assert.NoError(t, json.Unmarshal(b, &claims))
+		assert.NotContains(t, claims, "nbf")
+		assert.NotContains(t, claims, "climit")
+		assert.NotContains(t, claims, "uid")
+	})
+
+	t.Run("Missing required claims", func(t *testing.T) {
+		_, err := signer.Sign(&PlaybackTokenClaims{AccountID: "account-id"})
+		assert.Equal(t, ErrInvalidPlaybackClaims, err)
+	})
+}
+
+func TestPlaybackClient_WithToken(t *testing.T) {
+	signer, _ := newPlaybackTokenSignerMock(t)
+	token, err := signer.Sign(&PlaybackTokenClaims{
+		AccountID: "account-id",
+		ExpiresAt: time.Now().Add(time.Hour),
+	})
+	assert.NoError(t, err)
+
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer "+token, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "12345"}`)
+	}))
+	defer httpMock.Close()
+	playbackAuthBaseURL = httpMock.URL // change for test
+
+	pc := NewPlaybackClient("account-id", "", httpMock.Client())
+	video, err := pc.WithToken(token).GetVideo("12345")
+	assert.NoError(t, err)
+	assert.Equal(t, "12345", video.ID)
+	assert.Empty(t, pc.(*playbackClient).token)
+}
@@ -0,0 +1,383 @@
+package brighthub
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// PlayerClient client for Brightcove Player Management API
+	PlayerClient interface {
+		ListPlayers() ([]*Player, error)
+		GetPlayer(playerID string) (*Player, error)
+		CreatePlayer(req *CreatePlayerRequest) (*PlayerResponse, error)
+		UpdatePlayer(playerID string, req *UpdatePlayerRequest) (*Player, error)
+		DeletePlayer(playerID string) error
+		GetPlayerConfiguration(playerID string) (*PlayerConfiguration, error)
+		PatchPlayerConfiguration(playerID string, config *PlayerConfiguration) (*PlayerResponse, error)
+		PublishPlayer(playerID string) (*PlayerResponse, error)
+		ListPlayerEmbeds(playerID string) ([]*PlayerEmbed, error)
+	}
+
+	playerClient struct {
+		accountID   string
+		tokenSource TokenSource
+		httpClient  *http.Client
+	}
+
+	// Autoplay player autoplay behavior, marshalled as boolean for AutoplayOn and AutoplayOff
+	Autoplay string
+
+	// Player :nodoc:
+	Player struct {
+		ID          string          `json:"id"`
+		AccountID   string          `json:"account_id"`
+		Name        string          `json:"name"`
+		Description string          `json:"description"`
+		URL         string          `json:"url"`
+		EmbedCount  int64           `json:"embed_count"`
+		Branches    *PlayerBranches `json:"branches"`
+		CreatedAt   Timestamp       `json:"created_at"`
+	}
+
+	// PlayerBranches master is the published branch, preview is the branch being edited
+	PlayerBranches struct {
+		Master  *PlayerBranch `json:"master"`
+		Preview *PlayerBranch `json:"preview"`
+	}
+
+	// PlayerBranch :nodoc:
+	PlayerBranch struct {
+		Configuration    *PlayerConfiguration `json:"configuration"`
+		PreviewURL       string               `json:"preview_url,omitempty"`
+		PreviewEmbedCode string               `json:"preview_embed_code,omitempty"`
+		UpdatedAt        Timestamp            `json:"updated_at"`
+	}
+
+	// PlayerConfiguration player configuration, unset fields are left out so it can be used to patch configuration
+	PlayerConfiguration struct {
+		Autoplay      Autoplay               `json:"autoplay,omitempty"`
+		Muted         *bool                  `json:"muted,omitempty"`
+		Fluid         *bool                  `json:"fluid,omitempty"`
+		PlaybackRates []float64              `json:"playback_rates,omitempty"`
+		Plugins       []*PlayerPlugin        `json:"plugins,omitempty"`
+		Scripts       []string               `json:"scripts,omitempty"`
+		Stylesheets   []string               `json:"stylesheets,omitempty"`
+		CSS           *PlayerStyle           `json:"css,omitempty"`
+		Player        *PlayerTemplateOptions `json:"player,omitempty"`
+		VideoCloud    *PlayerVideoCloud      `json:"video_cloud,omitempty"`
+	}
+
+	// PlayerPlugin :nodoc:
+	PlayerPlugin struct {
+		Name       string                 `json:"name"`
+		RegistryID string                 `json:"registry_id,omitempty"`
+		Version    string                 `json:"version,omitempty"`
+		Options    map[string]interface{} `json:"options,omitempty"`
+	}
+
+	// PlayerStyle :nodoc:
+	PlayerStyle struct {
+		ControlBarColor string `json:"controlBarColor,omitempty"`
+		ControlColor    string `json:"controlColor,omitempty"`
+		ProgressColor   string `json:"progressColor,omitempty"`
+	}
+
+	// PlayerTemplateOptions :nodoc:
+	PlayerTemplateOptions struct {
+		Inactive bool            `json:"inactive,omitempty"`
+		Template *PlayerTemplate `json:"template,omitempty"`
+	}
+
+	// PlayerTemplate :nodoc:
+	PlayerTemplate struct {
+		Name    string `json:"name"`
+		Version string `json:"version"`
+	}
+
+	// PlayerVideoCloud :nodoc:
+	PlayerVideoCloud struct {
+		PolicyKey string `json:"policy_key,omitempty"`
+		Video     string `json:"video,omitempty"`
+		Playlist  string `json:"playlist,omitempty"`
+	}
+
+	// CreatePlayerRequest :nodoc:
+	CreatePlayerRequest struct {
+		Name          string               `json:"name"`
+		Description   string               `json:"description,omitempty"`
+		Configuration *PlayerConfiguration `json:"configuration,omitempty"`
+	}
+
+	// UpdatePlayerRequest :nodoc:
+	UpdatePlayerRequest struct {
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+	}
+
+	// PlayerResponse response of create, configuration patch and publish
+	PlayerResponse struct {
+		ID                 string `json:"id"`
+		URL                string `json:"url,omitempty"`
+		EmbedCode          string `json:"embed_code,omitempty"`
+		EmbedInPage        string `json:"embed_in_page,omitempty"`
+		PreviewURL         string `json:"preview_url,omitempty"`
+		PreviewEmbedCode   string `json:"preview_embed_code,omitempty"`
+		PreviewEmbedInPage string `json:"preview_embed_in_page,omitempty"`
+	}
+
+	// PlayerEmbed :nodoc:
+	PlayerEmbed struct {
+		ID        string          `json:"id"`
+		Name      string          `json:"name"`
+		IsDefault bool            `json:"is_default"`
+		Branches  *PlayerBranches `json:"branches"`
+	}
+
+	playerList struct {
+		ItemCount int64     `json:"item_count"`
+		Items     []*Player `json:"items"`
+	}
+
+	playerEmbedList struct {
+		ItemCount int64          `json:"item_count"`
+		Items     []*PlayerEmbed `json:"items"`
+	}
+)
+
+const (
+	// AutoplayOn :nodoc:
+	AutoplayOn Autoplay = "true"
+	// AutoplayOff :nodoc:
+	AutoplayOff Autoplay = "false"
+	// AutoplayPlay autoplay with sound, fails on browser which block it
+	AutoplayPlay Autoplay = "play"
+	// AutoplayMuted always autoplay muted
+	AutoplayMuted Autoplay = "muted"
+	// AutoplayAny autoplay with sound, fallback to muted
+	AutoplayAny Autoplay = "any"
+)
+
+var playerBaseURL = "https://players.api.brightcove.com/v2"
+
+// MarshalJSON :nodoc:
+func (a Autoplay) MarshalJSON() ([]byte, error) {
+	switch a {
+	case AutoplayOn, AutoplayOff:
+		return []byte(a), nil
+	default:
+		return json.Marshal(string(a))
+	}
+}
+
+// UnmarshalJSON :nodoc:
+func (a *Autoplay) UnmarshalJSON(b []byte) error {
+	var on bool
+	if err := json.Unmarshal(b, &on); err == nil {
+		*a = AutoplayOff
+		if on {
+			*a = AutoplayOn
+		}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*a = Autoplay(s)
+	return nil
+}
+
+// NewPlayerClient create player client, pass Client as tokenSource to share its access token
+func NewPlayerClient(accountID string, tokenSource TokenSource, httpClient *http.Client) PlayerClient {
+	c := &playerClient{
+		accountID:   accountID,
+		tokenSource: tokenSource,
+		httpClient:  httpClient,
+	}
+	if httpClient == nil {
+		c.httpClient = defaultHTTPClient
+	}
+	return c
+}
+
+// ListPlayers :nodoc:
+func (c *playerClient) ListPlayers() ([]*Player, error) {
+	list := new(playerList)
+	err := c.do("GET", "/players", nil, list)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+// GetPlayer :nodoc:
+func (c *playerClient) GetPlayer(playerID string) (*Player, error) {
+	player := new(Player)
+	err := c.do("GET", fmt.Sprintf("/players/%s", playerID), nil, player)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID}).
+			Error(err)
+		return nil, err
+	}
+	return player, nil
+}
+
+// CreatePlayer :nodoc:
+func (c *playerClient) CreatePlayer(req *CreatePlayerRequest) (*PlayerResponse, error) {
+	resp := new(PlayerResponse)
+	err := c.do("POST", "/players", req, resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// UpdatePlayer update player name and description
+func (c *playerClient) UpdatePlayer(playerID string, req *UpdatePlayerRequest) (*Player, error) {
+	player := new(Player)
+	err := c.do("PATCH", fmt.Sprintf("/players/%s", playerID), req, player)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID,
+			"request":  utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	return player, nil
+}
+
+// DeletePlayer :nodoc:
+func (c *playerClient) DeletePlayer(playerID string) error {
+	err := c.do("DELETE", fmt.Sprintf("/players/%s", playerID), nil, nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID}).
+			Error(err)
+		return err
+	}
+	return nil
+}
+
+// GetPlayerConfiguration get the preview configuration
+func (c *playerClient) GetPlayerConfiguration(playerID string) (*PlayerConfiguration, error) {
+	config := new(PlayerConfiguration)
+	err := c.do("GET", fmt.Sprintf("/players/%s/configuration", playerID), nil, config)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID}).
+			Error(err)
+		return nil, err
+	}
+	return config, nil
+}
+
+// PatchPlayerConfiguration merge config into the preview configuration, use PublishPlayer to make it live
+func (c *playerClient) PatchPlayerConfiguration(playerID string, config *PlayerConfiguration) (*PlayerResponse, error) {
+	resp := new(PlayerResponse)
+	err := c.do("PATCH", fmt.Sprintf("/players/%s/configuration", playerID), config, resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID":      playerID,
+			"configuration": utils.Dump(config)}).
+			Error(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// PublishPlayer publish the preview configuration
+func (c *playerClient) PublishPlayer(playerID string) (*PlayerResponse, error) {
+	resp := new(PlayerResponse)
+	err := c.do("POST", fmt.Sprintf("/players/%s/publish", playerID), nil, resp)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID}).
+			Error(err)
+		return nil, err
+	}
+	return resp, nil
+}
+
+// ListPlayerEmbeds :nodoc:
+func (c *playerClient) ListPlayerEmbeds(playerID string) ([]*PlayerEmbed, error) {
+	list := new(playerEmbedList)
+	err := c.do("GET", fmt.Sprintf("/players/%s/embeds", playerID), nil, list)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"playerID": playerID}).
+			Error(err)
+		return nil, err
+	}
+	return list.Items, nil
+}
+
+func (c *playerClient) do(method, path string, body, out interface{}) error {
+	token, err := c.tokenSource.AccessToken()
+	if err != nil {
+		return err
+	}
+
+	var b io.Reader
+	if body != nil {
+		buf := new(bytes.Buffer)
+		err = json.NewEncoder(buf).Encode(body)
+		if err != nil {
+			return err
+		}
+		b = buf
+	}
+
+	r, err := http.NewRequest(method, fmt.Sprintf("%s/accounts/%s%s", playerBaseURL, c.accountID, path), b)
+	if err != nil {
+		return err
+	}
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.httpClient.Do(r)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return ErrBadRequest
+		case http.StatusUnauthorized:
+			return ErrUnauthorized
+		case http.StatusForbidden:
+			return ErrNotAvailable
+		case http.StatusNotFound:
+			return ErrResourceNotFound
+		case http.StatusMethodNotAllowed:
+			return ErrMethodNotAllowed
+		case http.StatusUnprocessableEntity:
+			return ErrIllegalField
+		case http.StatusTooManyRequests:
+			return ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return ErrInternalError
+		default:
+			return fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
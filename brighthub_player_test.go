@@ -0,0 +1,181 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAutoplay_JSON(t *testing.T) {
+	for _, a := range []Autoplay{AutoplayOn, AutoplayOff, AutoplayMuted} {
+		b, err := json.Marshal(&PlayerConfiguration{Autoplay: a})
+		assert.NoError(t, err)
+
+		config := new(PlayerConfiguration)
+		assert.NoError(t, json.Unmarshal(b, config))
+		assert.Equal(t, a, config.Autoplay)
+	}
+
+	b, err := json.Marshal(&PlayerConfiguration{Autoplay: AutoplayOn})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"autoplay": true}`, string(b))
+
+	b, err = json.Marshal(&PlayerConfiguration{Autoplay: AutoplayMuted})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"autoplay": "muted"}`, string(b))
+}
+
+func TestPlayerClient_ListPlayers(t *testing.T) {
+	bh := newClientMock()
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/account-id/players", r.URL.Path)
+		assert.Equal(t, "Bearer "+bh.accessToken, r.Header.Get("Authorization"))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{
+			"item_count": 1,
+			"items": [{
+				"id": "default",
+				"name": "Article Embed",
+				"url": "https://players.brightcove.net/account-id/default_default/index.html",
+				"embed_count": 2,
+				"branches": {
+					"master": {
+						"configuration": {
+							"autoplay": "muted",
+							"fluid": true,
+							"plugins": [{"name": "ima3", "registry_id": "@brightcove/videojs-ima3", "version": "3.x", "options": {"serverUrl": "https://pubads.g.doubleclick.net"}}],
+							"css": {"controlBarColor": "#000000"},
+							"player": {"template": {"name": "single-video-template", "version": "6.30.0"}}
+						}
+					}
+				}
+			}]
+		}`)
+	}))
+	defer httpMock.Close()
+	playerBaseURL = httpMock.URL // change for test
+
+	pc := NewPlayerClient("account-id", bh, httpMock.Client())
+	players, err := pc.ListPlayers()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(players))
+
+	config := players[0].Branches.Master.Configuration
+	assert.Equal(t, AutoplayMuted, config.Autoplay)
+	assert.True(t, *config.Fluid)
+	assert.Equal(t, "ima3", config.Plugins[0].Name)
+	assert.Equal(t, "https://pubads.g.doubleclick.net", config.Plugins[0].Options["serverUrl"])
+	assert.Equal(t, "#000000", config.CSS.ControlBarColor)
+	assert.Equal(t, "6.30.0", config.Player.Template.Version)
+}
+
+func TestPlayerClient_CreatePlayer(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		req := new(CreatePlayerRequest)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(req))
+		assert.Equal(t, "Vertical Shorts", req.Name)
+		assert.Equal(t, AutoplayAny, req.Configuration.Autoplay)
+
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"id": "shorts-player", "url": "https://players.brightcove.net/account-id/shorts-player_default/index.html", "embed_code": "<iframe></iframe>"}`)
+	}))
+	defer httpMock.Close()
+	playerBaseURL = httpMock.URL // change for test
+
+	pc := NewPlayerClient("account-id", newClientMock(), httpMock.Client())
+	resp, err := pc.CreatePlayer(&CreatePlayerRequest{
+		Name:          "Vertical Shorts",
+		Configuration: &PlayerConfiguration{Autoplay: AutoplayAny},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "shorts-player", resp.ID)
+	assert.Equal(t, "<iframe></iframe>", resp.EmbedCode)
+}
+
+func TestPlayerClient_PatchPlayerConfiguration(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PATCH", r.Method)
+		assert.Equal(t, "/accounts/account-id/players/default/configuration", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"autoplay": false, "stylesheets": ["https://kumparan.com/player.css"]}`, string(b))
+
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "default", "preview_url": "https://preview-players.brightcove.net/v2/accounts/account-id/players/default/preview/embeds/default/master/index.html"}`)
+	}))
+	defer httpMock.Close()
+	playerBaseURL = httpMock.URL // change for test
+
+	pc := NewPlayerClient("account-id", newClientMock(), httpMock.Client())
+	resp, err := pc.PatchPlayerConfiguration("default", &PlayerConfiguration{
+		Autoplay:    AutoplayOff,
+		Stylesheets: []string{"https://kumparan.com/player.css"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "default", resp.ID)
+	assert.NotEmpty(t, resp.PreviewURL)
+}
+
+func TestPlayerClient_PublishPlayer(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		assert.Equal(t, "/accounts/account-id/players/default/publish", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "default", "url": "https://players.brightcove.net/account-id/default_default/index.html"}`)
+	}))
+	defer httpMock.Close()
+	playerBaseURL = httpMock.URL // change for test
+
+	pc := NewPlayerClient("account-id", newClientMock(), httpMock.Client())
+	resp, err := pc.PublishPlayer("default")
+	assert.NoError(t, err)
+	assert.Equal(t, "https://players.brightcove.net/account-id/default_default/index.html", resp.URL)
+}
+
+func TestPlayerClient_ListPlayerEmbeds(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/accounts/account-id/players/default/embeds", r.URL.Path)
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"item_count": 2, "items": [{"id": "default", "is_default": true}, {"id": "live-embed", "name": "Live"}]}`)
+	}))
+	defer httpMock.Close()
+	playerBaseURL = httpMock.URL // change for test
+
+	pc := NewPlayerClient("account-id", newClientMock(), httpMock.Client())
+	embeds, err := pc.ListPlayerEmbeds("default")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(embeds))
+	assert.True(t, embeds[0].IsDefault)
+	assert.Equal(t, "Live", embeds[1].Name)
+}
+
+func TestPlayerClient_DeletePlayer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "DELETE", r.Method)
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"message": "Successfully deleted player with the id: old-player"}`)
+		}))
+		defer httpMock.Close()
+		playerBaseURL = httpMock.URL // change for test
+
+		pc := NewPlayerClient("account-id", newClientMock(), httpMock.Client())
+		assert.NoError(t, pc.DeletePlayer("old-player"))
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer httpMock.Close()
+		playerBaseURL = httpMock.URL // change for test
+
+		pc := NewPlayerClient("account-id", newClientMock(), httpMock.Client())
+		assert.Equal(t, ErrResourceNotFound, pc.DeletePlayer("old-player"))
+	})
+}
@@ -0,0 +1,98 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// PlaylistType :nodoc:
+	PlaylistType string
+
+	// Playlist CMS playlist, manual playlist has VideoIDs while smart playlist has Search
+	Playlist struct {
+		ID          string       `json:"id"`
+		AccountID   string       `json:"account_id"`
+		Name        string       `json:"name"`
+		Description string       `json:"description"`
+		ReferenceID string       `json:"reference_id"`
+		Type        PlaylistType `json:"type"`
+		Favorite    bool         `json:"favorite"`
+		Search      string       `json:"search,omitempty"`
+		VideoIDs    []string     `json:"video_ids,omitempty"`
+		CreatedAt   Timestamp    `json:"created_at"`
+		UpdatedAt   Timestamp    `json:"updated_at"`
+	}
+)
+
+const (
+	// PlaylistTypeExplicit manual playlist
+	PlaylistTypeExplicit PlaylistType = "EXPLICIT"
+)
+
+// ListPlaylists a single page at most limit playlists is returned
+func (c *client) ListPlaylists(limit, offset int) ([]*Playlist, error) {
+	params := url.Values{}
+	if limit > 0 {
+		params.Set("limit", strconv.Itoa(limit))
+	}
+	if offset > 0 {
+		params.Set("offset", strconv.Itoa(offset))
+	}
+	u := fmt.Sprintf("%s/accounts/%s/playlists", cmsBaseURL, c.accountID)
+	if len(params) > 0 {
+		u += "?" + params.Encode()
+	}
+
+	r, err := c.newRequest("GET", u, nil)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"limit":  limit,
+			"offset": offset}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"limit":  limit,
+			"offset": offset}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return nil, ErrBadRequest
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var playlists []*Playlist
+	err = json.NewDecoder(resp.Body).Decode(&playlists)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"limit":  limit,
+			"offset": offset}).
+			Error(err)
+		return nil, err
+	}
+	return playlists, nil
+}
@@ -0,0 +1,31 @@
+package brighthub
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_ListPlaylists(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/playlists")
+		assert.Equal(t, "100", r.URL.Query().Get("limit"))
+		assert.Equal(t, "200", r.URL.Query().Get("offset"))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `[{"id": "pl-1", "name": "Pilihan Redaksi", "type": "EXPLICIT", "video_ids": ["1", "2"]}]`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	playlists, err := bh.ListPlaylists(100, 200)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(playlists))
+	assert.Equal(t, PlaylistTypeExplicit, playlists[0].Type)
+	assert.Equal(t, []string{"1", "2"}, playlists[0].VideoIDs)
+}
@@ -0,0 +1,195 @@
+package brighthub
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// PreflightProblemCode :nodoc:
+	PreflightProblemCode string
+
+	// IngestPreflightReport result of checking an ingest request before submitting it
+	IngestPreflightReport struct {
+		Profile *IngestProfile
+		// DefaultProfile account default profile, nil when the account has none
+		DefaultProfile         *IngestProfile
+		DynamicDeliveryEnabled bool
+		MasterContentType      string
+		MasterSize             int64
+		Problems               []*PreflightProblem
+	}
+
+	// PreflightProblem :nodoc:
+	PreflightProblem struct {
+		Code    PreflightProblemCode
+		Message string
+	}
+)
+
+const (
+	// PreflightProfileNotFound :nodoc:
+	PreflightProfileNotFound PreflightProblemCode = "PROFILE_NOT_FOUND"
+	// PreflightDynamicDeliveryNotAllowed :nodoc:
+	PreflightDynamicDeliveryNotAllowed PreflightProblemCode = "DYNAMIC_DELIVERY_NOT_ALLOWED"
+	// PreflightMasterMissing :nodoc:
+	PreflightMasterMissing PreflightProblemCode = "MASTER_MISSING"
+	// PreflightMasterUnreachable :nodoc:
+	PreflightMasterUnreachable PreflightProblemCode = "MASTER_UNREACHABLE"
+	// PreflightMasterContentType :nodoc:
+	PreflightMasterContentType PreflightProblemCode = "MASTER_CONTENT_TYPE"
+	// PreflightMasterEmpty :nodoc:
+	PreflightMasterEmpty PreflightProblemCode = "MASTER_EMPTY"
+)
+
+// OK true when no problem found
+func (r *IngestPreflightReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+func (r *IngestPreflightReport) addProblem(code PreflightProblemCode, format string, args ...interface{}) {
+	r.Problems = append(r.Problems, &PreflightProblem{
+		Code:    code,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// PreflightIngestVideo check the ingest request without submitting it.
+// Problems with the request are listed in the report, error is only returned when the check itself cannot be done.
+// The account is considered Dynamic Delivery enabled when its default profile is a Dynamic Delivery profile,
+// the profile compatibility is not checked when the account has no default profile.
+func (c *client) PreflightIngestVideo(req *IngestVideoRequest) (*IngestPreflightReport, error) {
+	report := new(IngestPreflightReport)
+
+	configuration, err := c.GetDefaultIngestProfile()
+	if err != nil && err != ErrResourceNotFound {
+		log.WithFields(log.Fields{
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	if configuration != nil && configuration.DefaultProfileID != "" {
+		report.DefaultProfile, err = c.GetIngestProfile(configuration.DefaultProfileID)
+		if err != nil && err != ErrResourceNotFound {
+			log.WithFields(log.Fields{
+				"request": utils.Dump(req)}).
+				Error(err)
+			return nil, err
+		}
+		report.DynamicDeliveryEnabled = report.DefaultProfile.IsDynamicDelivery()
+	}
+
+	switch {
+	case req.Profile == "":
+		report.Profile = report.DefaultProfile
+	case report.DefaultProfile != nil && (req.Profile == report.DefaultProfile.ID || req.Profile == report.DefaultProfile.Name):
+		report.Profile = report.DefaultProfile
+	default:
+		report.Profile, err = c.GetIngestProfile(req.Profile)
+		switch {
+		case err == ErrResourceNotFound:
+			report.addProblem(PreflightProfileNotFound, "profile %s does not exist", req.Profile)
+		case err != nil:
+			log.WithFields(log.Fields{
+				"request": utils.Dump(req)}).
+				Error(err)
+			return nil, err
+		case report.DefaultProfile != nil && report.Profile.IsDynamicDelivery() && !report.DynamicDeliveryEnabled:
+			report.addProblem(PreflightDynamicDeliveryNotAllowed, "profile %s is a Dynamic Delivery profile but the account is not enabled for Dynamic Delivery", req.Profile)
+		}
+	}
+
+	switch {
+	case req.Master != nil && req.Master.UseArchivedMaster:
+		// archived master lives in Brightcove, nothing to check
+		return report, nil
+	case req.Master == nil || req.Master.URL == "":
+		report.addProblem(PreflightMasterMissing, "master url is empty")
+		return report, nil
+	}
+	c.checkMasterURL(req.Master.URL, report)
+
+	return report, nil
+}
+
+// checkMasterURL send HEAD, or a single byte GET when HEAD is rejected as pre-signed GET urls do
+func (c *client) checkMasterURL(url string, report *IngestPreflightReport) {
+	resp, err := c.requestMaster("HEAD", url)
+	if err != nil {
+		report.addProblem(PreflightMasterUnreachable, "master url unreachable: %s", err)
+		return
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusMethodNotAllowed {
+		resp, err = c.requestMaster("GET", url)
+		if err != nil {
+			report.addProblem(PreflightMasterUnreachable, "master url unreachable: %s", err)
+			return
+		}
+		resp.Body.Close()
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		report.addProblem(PreflightMasterUnreachable, "master url responded with code %d", resp.StatusCode)
+		return
+	}
+
+	report.MasterContentType = resp.Header.Get("Content-Type")
+	report.MasterSize = resp.ContentLength
+	if resp.StatusCode == http.StatusPartialContent {
+		report.MasterSize = contentRangeSize(resp.Header.Get("Content-Range"))
+	}
+	if !isMediaContentType(report.MasterContentType) {
+		report.addProblem(PreflightMasterContentType, "master url content type %q is not a media type", report.MasterContentType)
+	}
+	if report.MasterSize == 0 {
+		report.addProblem(PreflightMasterEmpty, "master url content is empty")
+	}
+}
+
+func (c *client) requestMaster(method, url string) (*http.Response, error) {
+	r, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if method == "GET" {
+		r.Header.Set("Range", "bytes=0-0")
+	}
+	return c.do(r.WithContext(c.context()))
+}
+
+// contentRangeSize complete length of "bytes 0-0/1024", -1 when unknown
+func contentRangeSize(contentRange string) int64 {
+	i := strings.LastIndex(contentRange, "/")
+	if i < 0 {
+		return -1
+	}
+	size, err := strconv.ParseInt(contentRange[i+1:], 10, 64)
+	if err != nil {
+		return -1
+	}
+	return size
+}
+
+func isMediaContentType(contentType string) bool {
+	contentType = strings.ToLower(strings.TrimSpace(strings.Split(contentType, ";")[0]))
+	switch {
+	case contentType == "":
+		// unknown content type, let Brightcove decide
+		return true
+	case strings.HasPrefix(contentType, "video/"), strings.HasPrefix(contentType, "audio/"):
+		return true
+	case contentType == "application/octet-stream", contentType == "binary/octet-stream",
+		contentType == "application/x-mpegurl", contentType == "application/vnd.apple.mpegurl",
+		contentType == "application/dash+xml", contentType == "application/mxf":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,153 @@
+package brighthub
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newPreflightMock(t *testing.T, dynamicDeliveryEnabled bool, masterContentType string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "HEAD" && r.URL.Path == "/master.mp4":
+			w.Header().Set("Content-Type", masterContentType)
+			w.Header().Set("Content-Length", "1024")
+			w.WriteHeader(http.StatusOK)
+		case r.URL.Path == "/presigned.mp4":
+			// pre-signed GET url, signature doesn't match HEAD
+			if r.Method != "GET" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			assert.Equal(t, "bytes=0-0", r.Header.Get("Range"))
+			w.Header().Set("Content-Type", masterContentType)
+			w.Header().Set("Content-Range", "bytes 0-0/2048")
+			w.WriteHeader(http.StatusPartialContent)
+			io.WriteString(w, "0")
+		case strings.HasPrefix(r.URL.Path, "/no-default/") && strings.HasSuffix(r.URL.Path, "/configuration"):
+			w.WriteHeader(http.StatusNotFound)
+		case strings.HasSuffix(r.URL.Path, "/configuration"):
+			w.WriteHeader(http.StatusOK)
+			if dynamicDeliveryEnabled {
+				io.WriteString(w, `{"default_profile_id": "multi-platform-standard-dynamic"}`)
+				return
+			}
+			io.WriteString(w, `{"default_profile_id": "multi-platform-standard-static"}`)
+		case strings.HasSuffix(r.URL.Path, "/profiles/multi-platform-standard-dynamic"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "multi-platform-standard-dynamic", "name": "multi-platform-standard-dynamic", "brightcove_standard": true, "dynamic_origin": {"renditions": ["default/video450"]}}`)
+		case strings.HasSuffix(r.URL.Path, "/profiles/multi-platform-standard-static"):
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "multi-platform-standard-static", "name": "multi-platform-standard-static", "brightcove_standard": true, "renditions": [{"media_type": "video"}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+}
+
+func TestClient_PreflightIngestVideo(t *testing.T) {
+	t.Run("OK", func(t *testing.T) {
+		httpMock := newPreflightMock(t, true, "video/mp4")
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		report, err := bh.PreflightIngestVideo(&IngestVideoRequest{
+			Master:  &IngestVideoMaster{URL: httpMock.URL + "/master.mp4"},
+			Profile: "multi-platform-standard-dynamic",
+		})
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.True(t, report.DynamicDeliveryEnabled)
+		assert.Equal(t, "multi-platform-standard-dynamic", report.Profile.Name)
+		assert.Equal(t, "video/mp4", report.MasterContentType)
+		assert.EqualValues(t, 1024, report.MasterSize)
+	})
+
+	t.Run("Default profile", func(t *testing.T) {
+		httpMock := newPreflightMock(t, false, "video/mp4")
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		report, err := bh.PreflightIngestVideo(&IngestVideoRequest{
+			Master: &IngestVideoMaster{URL: httpMock.URL + "/master.mp4"},
+		})
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.False(t, report.DynamicDeliveryEnabled)
+		assert.Equal(t, "multi-platform-standard-static", report.Profile.Name)
+		assert.Equal(t, report.DefaultProfile, report.Profile)
+	})
+
+	t.Run("Problems", func(t *testing.T) {
+		httpMock := newPreflightMock(t, false, "text/html; charset=utf-8")
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		report, err := bh.PreflightIngestVideo(&IngestVideoRequest{
+			Master:  &IngestVideoMaster{URL: httpMock.URL + "/master.mp4"},
+			Profile: "multi-platform-standard-dynamic",
+		})
+		assert.NoError(t, err)
+		assert.False(t, report.OK())
+		assert.Equal(t, 2, len(report.Problems))
+		assert.Equal(t, PreflightDynamicDeliveryNotAllowed, report.Problems[0].Code)
+		assert.Equal(t, PreflightMasterContentType, report.Problems[1].Code)
+
+		report, err = bh.PreflightIngestVideo(&IngestVideoRequest{
+			Master:  &IngestVideoMaster{URL: httpMock.URL + "/not-found.mp4"},
+			Profile: "not-exist",
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 2, len(report.Problems))
+		assert.Equal(t, PreflightProfileNotFound, report.Problems[0].Code)
+		assert.Equal(t, PreflightMasterUnreachable, report.Problems[1].Code)
+	})
+
+	t.Run("Pre-signed master", func(t *testing.T) {
+		httpMock := newPreflightMock(t, true, "video/mp4")
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		report, err := bh.PreflightIngestVideo(&IngestVideoRequest{
+			Master: &IngestVideoMaster{URL: httpMock.URL + "/presigned.mp4"},
+		})
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.Equal(t, "video/mp4", report.MasterContentType)
+		assert.EqualValues(t, 2048, report.MasterSize)
+	})
+
+	t.Run("No default profile", func(t *testing.T) {
+		httpMock := newPreflightMock(t, false, "video/mp4")
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL + "/no-default" // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		report, err := bh.PreflightIngestVideo(&IngestVideoRequest{
+			Master:  &IngestVideoMaster{URL: httpMock.URL + "/master.mp4"},
+			Profile: "multi-platform-standard-dynamic",
+		})
+		assert.NoError(t, err)
+		assert.True(t, report.OK())
+		assert.Nil(t, report.DefaultProfile)
+		assert.Equal(t, "multi-platform-standard-dynamic", report.Profile.Name)
+	})
+}
@@ -0,0 +1,467 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// IngestProfile :nodoc:
+	IngestProfile struct {
+		ID                 string `json:"id,omitempty"`
+		Version            int64  `json:"version,omitempty"`
+		Name               string `json:"name"`
+		DisplayName        string `json:"display_name"`
+		Description        string `json:"description"`
+		AccountID          int64  `json:"account_id,omitempty"`
+		BrightcoveStandard bool   `json:"brightcove_standard,omitempty"`
+		// DateCreated and DateLastModified are read only, nil on profiles which are not returned by Brightcove
+		DateCreated      *UnixTimestamp `json:"date_created,omitempty"`
+		DateLastModified *UnixTimestamp `json:"date_last_modified,omitempty"`
+		DigitalMaster    *DigitalMaster `json:"digital_master,omitempty"`
+		// DynamicOrigin is empty on legacy profiles, see IsDynamicDelivery
+		DynamicOrigin DynamicOrigin `json:"dynamic_origin"`
+		// Renditions and Packages only exist on legacy (non Dynamic Delivery) profiles
+		Renditions []*ProfileRendition `json:"renditions,omitempty"`
+		Packages   []*ProfilePackage   `json:"packages,omitempty"`
+	}
+
+	// DigitalMaster :nodoc:
+	DigitalMaster struct {
+		Rendition  string `json:"rendition,omitempty"`
+		Distribute bool   `json:"distribute"`
+	}
+
+	// DynamicOrigin :nodoc:
+	DynamicOrigin struct {
+		Renditions            []string               `json:"renditions"`
+		Images                []*ProfileImage        `json:"images,omitempty"`
+		DynamicProfileOptions *DynamicProfileOptions `json:"dynamic_profile_options,omitempty"`
+	}
+
+	// ProfileImage :nodoc:
+	ProfileImage struct {
+		Label  string `json:"label"`
+		Height int64  `json:"height"`
+		Width  int64  `json:"width"`
+	}
+
+	// DynamicProfileOptions options for context aware encoding profile
+	DynamicProfileOptions struct {
+		MinRenditions                      int64       `json:"min_renditions,omitempty"`
+		MaxRenditions                      int64       `json:"max_renditions,omitempty"`
+		MinResolution                      *Resolution `json:"min_resolution,omitempty"`
+		MaxResolution                      *Resolution `json:"max_resolution,omitempty"`
+		MaxFrameRate                       float64     `json:"max_frame_rate,omitempty"`
+		MaxBitrate                         int64       `json:"max_bitrate,omitempty"`
+		MaxFirstRenditionBitrate           int64       `json:"max_first_rendition_bitrate,omitempty"`
+		KeyframeRate                       float64     `json:"keyframe_rate,omitempty"`
+		SelectBaselineProfileConfiguration bool        `json:"select_baseline_profile_configuration,omitempty"`
+		VideoCodec                         string      `json:"video_codec,omitempty"`
+		SelectionMode                      string      `json:"selection_mode,omitempty"`
+	}
+
+	// Resolution :nodoc:
+	Resolution struct {
+		Width  int64 `json:"width"`
+		Height int64 `json:"height"`
+	}
+
+	// ProfileRendition legacy ingest profile rendition
+	ProfileRendition struct {
+		ReferenceID       string  `json:"reference_id,omitempty"`
+		Label             string  `json:"label,omitempty"`
+		MediaType         string  `json:"media_type,omitempty"`
+		Format            string  `json:"format,omitempty"`
+		VideoCodec        string  `json:"video_codec,omitempty"`
+		VideoBitrate      int64   `json:"video_bitrate,omitempty"`
+		AudioCodec        string  `json:"audio_codec,omitempty"`
+		AudioBitrate      int64   `json:"audio_bitrate,omitempty"`
+		Width             int64   `json:"width,omitempty"`
+		Height            int64   `json:"height,omitempty"`
+		H264Profile       string  `json:"h264_profile,omitempty"`
+		KeyframeRate      float64 `json:"keyframe_rate,omitempty"`
+		MaxFrameRate      float64 `json:"max_frame_rate,omitempty"`
+		DecoderBitrateCap int64   `json:"decoder_bitrate_cap,omitempty"`
+		DecoderBufferSize int64   `json:"decoder_buffer_size,omitempty"`
+		Speed             int64   `json:"speed,omitempty"`
+	}
+
+	// ProfilePackage legacy ingest profile package
+	ProfilePackage struct {
+		PackageType string   `json:"package_type"`
+		Renditions  []string `json:"renditions,omitempty"`
+	}
+
+	// IngestProfileConfiguration account ingest profile configuration
+	IngestProfileConfiguration struct {
+		ID               string `json:"id,omitempty"`
+		AccountID        string `json:"account_id"`
+		DefaultProfileID string `json:"default_profile_id"`
+		Version          int64  `json:"version,omitempty"`
+		// DateCreated and DateLastModified are read only, nil on configurations which are not returned by Brightcove
+		DateCreated      *UnixTimestamp `json:"date_created,omitempty"`
+		DateLastModified *UnixTimestamp `json:"date_last_modified,omitempty"`
+	}
+)
+
+var ingestionBaseURL = "https://ingestion.api.brightcove.com/v1"
+
+// IsDynamicDelivery whether the profile is a Dynamic Delivery profile
+func (p *IngestProfile) IsDynamicDelivery() bool {
+	return p != nil && (len(p.DynamicOrigin.Renditions) > 0 || len(p.DynamicOrigin.Images) > 0 ||
+		p.DynamicOrigin.DynamicProfileOptions != nil)
+}
+
+// MarshalJSON omit dynamic_origin of legacy profiles
+func (p IngestProfile) MarshalJSON() ([]byte, error) {
+	type profile IngestProfile
+	if p.IsDynamicDelivery() {
+		return json.Marshal(profile(p))
+	}
+	return json.Marshal(struct {
+		profile
+		DynamicOrigin *DynamicOrigin `json:"dynamic_origin,omitempty"`
+	}{profile: profile(p)})
+}
+
+// GetIngestProfile :nodoc:
+func (c *client) GetIngestProfile(id string) (*IngestProfile, error) {
+	if id == "" {
+		return nil, ErrProfileIDRequired
+	}
+
+	token, err := c.getAccessToken()
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		return nil, err
+	}
+
+	r, err := http.NewRequest("GET", fmt.Sprintf("%s/accounts/%s/profiles/%s", ingestionBaseURL, c.accountID, id), nil)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		return nil, err
+	}
+	r = r.WithContext(c.context())
+	r.Header.Set("Content-Type", "application/json")
+	r.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusConflict:
+			return nil, ErrProfileError
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		case http.StatusTooManyRequests:
+			return nil, ErrRateLimitExceeded
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	ingestProfile := new(IngestProfile)
+	err = json.NewDecoder(resp.Body).Decode(&ingestProfile)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		return nil, err
+	}
+
+	return ingestProfile, nil
+}
+
+// ListIngestProfiles :nodoc:
+func (c *client) ListIngestProfiles() ([]*IngestProfile, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/profiles", ingestionBaseURL, c.accountID), nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		case http.StatusTooManyRequests:
+			return nil, ErrRateLimitExceeded
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	var ingestProfiles []*IngestProfile
+	err = json.NewDecoder(resp.Body).Decode(&ingestProfiles)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return ingestProfiles, nil
+}
+
+// CreateIngestProfile :nodoc:
+func (c *client) CreateIngestProfile(profile *IngestProfile) (*IngestProfile, error) {
+	r, err := c.newRequest("POST", fmt.Sprintf("%s/accounts/%s/profiles", ingestionBaseURL, c.accountID), profile)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"profile": utils.Dump(profile)}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"profile": utils.Dump(profile)}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return nil, ErrBadRequest
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusConflict:
+			return nil, ErrProfileError
+		case http.StatusUnprocessableEntity:
+			return nil, ErrIllegalField
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		case http.StatusTooManyRequests:
+			return nil, ErrRateLimitExceeded
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	ingestProfile := new(IngestProfile)
+	err = json.NewDecoder(resp.Body).Decode(&ingestProfile)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"profile": utils.Dump(profile)}).
+			Error(err)
+		return nil, err
+	}
+
+	return ingestProfile, nil
+}
+
+// UpdateIngestProfile update the profile identified by profile.ID
+func (c *client) UpdateIngestProfile(profile *IngestProfile) (*IngestProfile, error) {
+	if profile.ID == "" {
+		return nil, ErrProfileIDRequired
+	}
+
+	r, err := c.newRequest("PUT", fmt.Sprintf("%s/accounts/%s/profiles/%s", ingestionBaseURL, c.accountID, profile.ID), profile)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"profile": utils.Dump(profile)}).
+			Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"profile": utils.Dump(profile)}).
+			Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return nil, ErrBadRequest
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusConflict:
+			return nil, ErrProfileError
+		case http.StatusUnprocessableEntity:
+			return nil, ErrIllegalField
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		case http.StatusTooManyRequests:
+			return nil, ErrRateLimitExceeded
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	ingestProfile := new(IngestProfile)
+	err = json.NewDecoder(resp.Body).Decode(&ingestProfile)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"profile": utils.Dump(profile)}).
+			Error(err)
+		return nil, err
+	}
+
+	return ingestProfile, nil
+}
+
+// DeleteIngestProfile :nodoc:
+func (c *client) DeleteIngestProfile(id string) error {
+	if id == "" {
+		return ErrProfileIDRequired
+	}
+
+	r, err := c.newRequest("DELETE", fmt.Sprintf("%s/accounts/%s/profiles/%s", ingestionBaseURL, c.accountID, id), nil)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		return err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": id}).Error(err)
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return ErrUnauthorized
+		case http.StatusNotFound:
+			return ErrResourceNotFound
+		case http.StatusConflict:
+			return ErrProfileError
+		case http.StatusInternalServerError:
+			return ErrInternalError
+		case http.StatusTooManyRequests:
+			return ErrRateLimitExceeded
+		default:
+			return fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	return nil
+}
+
+// GetDefaultIngestProfile get account ingest profile configuration which holds the default profile id
+func (c *client) GetDefaultIngestProfile() (*IngestProfileConfiguration, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/configuration", ingestionBaseURL, c.accountID), nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		case http.StatusTooManyRequests:
+			return nil, ErrRateLimitExceeded
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	configuration := new(IngestProfileConfiguration)
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	return configuration, nil
+}
+
+// SetDefaultIngestProfile set account default ingest profile.
+// The account configuration is updated, or created when the account doesn't have one yet.
+func (c *client) SetDefaultIngestProfile(id string) (*IngestProfileConfiguration, error) {
+	configuration, err := c.putIngestProfileConfiguration("PUT", id)
+	if err == ErrResourceNotFound {
+		configuration, err = c.putIngestProfileConfiguration("POST", id)
+	}
+	return configuration, err
+}
+
+func (c *client) putIngestProfileConfiguration(method, profileID string) (*IngestProfileConfiguration, error) {
+	req := &IngestProfileConfiguration{
+		AccountID:        c.accountID,
+		DefaultProfileID: profileID,
+	}
+	r, err := c.newRequest(method, fmt.Sprintf("%s/accounts/%s/configuration", ingestionBaseURL, c.accountID), req)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": profileID}).Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": profileID}).Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		switch resp.StatusCode {
+		case http.StatusBadRequest:
+			return nil, ErrBadRequest
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusNotFound:
+			return nil, ErrResourceNotFound
+		case http.StatusConflict:
+			return nil, ErrProfileError
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		case http.StatusTooManyRequests:
+			return nil, ErrRateLimitExceeded
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	configuration := new(IngestProfileConfiguration)
+	err = json.NewDecoder(resp.Body).Decode(&configuration)
+	if err != nil {
+		log.WithFields(log.Fields{"profileID": profileID}).Error(err)
+		return nil, err
+	}
+
+	return configuration, nil
+}
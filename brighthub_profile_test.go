@@ -0,0 +1,328 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetIngestProfile(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"name": "multi-platform-standard-static",
+			"display_name": "Multiplatform Standard",
+			"description": "Deliver a wide range of content types across a variety of platforms on mobile and desktop.",
+			"dynamic_origin": {
+				"renditions": [
+					"default/audio64",
+					"default/audio128",
+					"default/video700",
+					"default/video2000",
+					"default/video1700",
+					"default/video1200",
+					"default/audio96",
+					"default/video450",
+					"default/video900"
+					]
+			}
+		}`)
+	}))
+	defer httpMock.Close()
+	ingestionBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	resp, err := bh.GetIngestProfile("id-ingest-profile")
+	assert.NoError(t, err)
+	assert.Equal(t, "multi-platform-standard-static", resp.Name)
+	assert.Equal(t, "Multiplatform Standard", resp.DisplayName)
+	assert.Equal(t, "Deliver a wide range of content types across a variety of platforms on mobile and desktop.", resp.Description)
+	assert.Equal(t, 9, len(resp.DynamicOrigin.Renditions))
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/audio64")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/audio128")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video700")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video2000")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video1700")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video1200")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/audio96")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video450")
+	assert.Contains(t, resp.DynamicOrigin.Renditions, "default/video900")
+}
+
+func TestClient_ListIngestProfiles(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `[
+			{
+				"id": "0b6a1bba-58d8-4d1a-b3bb-5a4b8fa9a1b5",
+				"name": "multi-platform-standard-dynamic",
+				"display_name": "Multiplatform Standard",
+				"brightcove_standard": true,
+				"dynamic_origin": {
+					"renditions": ["default/audio64", "default/video450"],
+					"images": [{"label": "poster", "height": 720, "width": 1280}],
+					"dynamic_profile_options": {
+						"min_renditions": 2,
+						"max_renditions": 6,
+						"min_resolution": {"width": 320, "height": 180},
+						"max_resolution": {"width": 1920, "height": 1080},
+						"max_bitrate": 4000,
+						"max_first_rendition_bitrate": 250,
+						"selection_mode": "optimal"
+					}
+				}
+			},
+			{
+				"id": "76e03c4a-2dc5-4b9f-8b34-8fa31b1b7e9c",
+				"name": "legacy-profile",
+				"renditions": [
+					{"media_type": "video", "format": "mp4", "video_codec": "h264", "video_bitrate": 1200, "width": 960, "height": 540}
+				],
+				"packages": [{"package_type": "hls", "renditions": ["rendition-1"]}]
+			}
+		]`)
+	}))
+	defer httpMock.Close()
+	ingestionBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	resp, err := bh.ListIngestProfiles()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(resp))
+
+	dynamic := resp[0]
+	assert.Equal(t, "multi-platform-standard-dynamic", dynamic.Name)
+	assert.True(t, dynamic.BrightcoveStandard)
+	assert.Equal(t, 2, len(dynamic.DynamicOrigin.Renditions))
+	assert.Equal(t, "poster", dynamic.DynamicOrigin.Images[0].Label)
+	assert.EqualValues(t, 1280, dynamic.DynamicOrigin.Images[0].Width)
+	options := dynamic.DynamicOrigin.DynamicProfileOptions
+	assert.EqualValues(t, 2, options.MinRenditions)
+	assert.EqualValues(t, 6, options.MaxRenditions)
+	assert.EqualValues(t, 320, options.MinResolution.Width)
+	assert.EqualValues(t, 1080, options.MaxResolution.Height)
+	assert.EqualValues(t, 4000, options.MaxBitrate)
+	assert.EqualValues(t, 250, options.MaxFirstRenditionBitrate)
+	assert.Equal(t, "optimal", options.SelectionMode)
+
+	legacy := resp[1]
+	assert.False(t, legacy.IsDynamicDelivery())
+	assert.Empty(t, legacy.DynamicOrigin.Renditions)
+	assert.Equal(t, "h264", legacy.Renditions[0].VideoCodec)
+	assert.EqualValues(t, 1200, legacy.Renditions[0].VideoBitrate)
+	assert.Equal(t, "hls", legacy.Packages[0].PackageType)
+}
+
+func TestClient_CreateIngestProfile(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "POST", r.Method)
+		b, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+		// read only dates are not sent
+		assert.NotContains(t, string(b), "date_created")
+		assert.NotContains(t, string(b), "date_last_modified")
+		profile := new(IngestProfile)
+		assert.NoError(t, json.Unmarshal(b, profile))
+		profile.ID = "id-profile-lucu"
+		profile.Version = 1
+
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(profile)
+	}))
+	defer httpMock.Close()
+	ingestionBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	resp, err := bh.CreateIngestProfile(&IngestProfile{
+		Name:        "kumparan-dynamic",
+		DisplayName: "Kumparan Dynamic",
+		DynamicOrigin: DynamicOrigin{
+			Renditions: []string{"default/audio64", "default/video900"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "id-profile-lucu", resp.ID)
+	assert.EqualValues(t, 1, resp.Version)
+	assert.Equal(t, "kumparan-dynamic", resp.Name)
+	assert.Equal(t, []string{"default/audio64", "default/video900"}, resp.DynamicOrigin.Renditions)
+}
+
+func TestClient_UpdateIngestProfile(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "PUT", r.Method)
+			assert.Contains(t, r.URL.Path, "/profiles/id-profile-lucu")
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(b), "dynamic_origin") // legacy profile
+			profile := new(IngestProfile)
+			assert.NoError(t, json.Unmarshal(b, profile))
+			profile.Version = 2
+
+			w.WriteHeader(http.StatusOK)
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(profile)
+		}))
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		resp, err := bh.UpdateIngestProfile(&IngestProfile{
+			ID:          "id-profile-lucu",
+			Name:        "kumparan-dynamic",
+			Description: "updated",
+		})
+		assert.NoError(t, err)
+		assert.EqualValues(t, 2, resp.Version)
+		assert.Equal(t, "updated", resp.Description)
+	})
+
+	t.Run("Not Found", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNotFound)
+		}))
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		_, err := bh.UpdateIngestProfile(&IngestProfile{ID: "id-profile-lucu"})
+		assert.Equal(t, ErrResourceNotFound, err)
+	})
+
+	t.Run("Empty ID", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}))
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		_, err := bh.UpdateIngestProfile(&IngestProfile{Name: "kumparan-dynamic"})
+		assert.Equal(t, ErrProfileIDRequired, err)
+		_, err = bh.GetIngestProfile("")
+		assert.Equal(t, ErrProfileIDRequired, err)
+		assert.Equal(t, ErrProfileIDRequired, bh.DeleteIngestProfile(""))
+	})
+}
+
+func TestClient_DeleteIngestProfile(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Contains(t, r.URL.Path, "/profiles/id-profile-lucu")
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer httpMock.Close()
+	ingestionBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	err := bh.DeleteIngestProfile("id-profile-lucu")
+	assert.NoError(t, err)
+}
+
+func TestClient_GetDefaultIngestProfile(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Header().Set("Content-Type", "application/json")
+		io.WriteString(w, `{
+			"id": "58eff8bd-2a1b-4d26-9ea0-b8c5b3a3c9cb",
+			"account_id": "account-id-kamu",
+			"default_profile_id": "multi-platform-standard-dynamic",
+			"version": 3,
+			"date_created": 1556618952548,
+			"date_last_modified": 1556618952548
+		}`)
+	}))
+	defer httpMock.Close()
+	ingestionBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	resp, err := bh.GetDefaultIngestProfile()
+	assert.NoError(t, err)
+	assert.Equal(t, "account-id-kamu", resp.AccountID)
+	assert.Equal(t, "multi-platform-standard-dynamic", resp.DefaultProfileID)
+	assert.EqualValues(t, 3, resp.Version)
+	assert.Equal(t, int64(1556618952548), resp.DateCreated.UnixMilliseconds())
+	assert.Equal(t, 2019, resp.DateLastModified.Year())
+}
+
+func TestClient_SetDefaultIngestProfile(t *testing.T) {
+	t.Run("Update", func(t *testing.T) {
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "PUT", r.Method)
+			b, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			body := map[string]interface{}{}
+			assert.NoError(t, json.Unmarshal(b, &body))
+			// read only dates are not sent
+			assert.NotContains(t, body, "date_created")
+			assert.NotContains(t, body, "date_last_modified")
+			assert.Equal(t, "kumparan-dynamic", body["default_profile_id"])
+			configuration := new(IngestProfileConfiguration)
+			assert.NoError(t, json.Unmarshal(b, configuration))
+
+			w.WriteHeader(http.StatusOK)
+			json.NewEncoder(w).Encode(configuration)
+		}))
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		resp, err := bh.SetDefaultIngestProfile("kumparan-dynamic")
+		assert.NoError(t, err)
+		assert.Equal(t, "kumparan-dynamic", resp.DefaultProfileID)
+		assert.Equal(t, bh.accountID, resp.AccountID)
+	})
+
+	t.Run("Create when account has no configuration", func(t *testing.T) {
+		var methods []string
+		httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			methods = append(methods, r.Method)
+			if r.Method == "PUT" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			configuration := new(IngestProfileConfiguration)
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(configuration))
+
+			w.WriteHeader(http.StatusCreated)
+			json.NewEncoder(w).Encode(configuration)
+		}))
+		defer httpMock.Close()
+		ingestionBaseURL = httpMock.URL // change for test
+
+		bh := newClientMock()
+		bh.httpClient = httpMock.Client()
+
+		resp, err := bh.SetDefaultIngestProfile("kumparan-dynamic")
+		assert.NoError(t, err)
+		assert.Equal(t, "kumparan-dynamic", resp.DefaultProfileID)
+		assert.Equal(t, []string{"PUT", "POST"}, methods)
+	})
+}
@@ -0,0 +1,117 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// VideoSchedule availability window, nil StartsAt means available immediately and nil EndsAt means never expire
+	VideoSchedule struct {
+		StartsAt *time.Time
+		EndsAt   *time.Time
+	}
+
+	// ScheduledVideos :nodoc:
+	ScheduledVideos struct {
+		// GoingLive videos whose schedule starts within the window
+		GoingLive []*Video
+		// Expiring videos whose schedule ends within the window
+		Expiring []*Video
+	}
+
+	videoScheduleJSON struct {
+		StartsAt *string `json:"starts_at"`
+		EndsAt   *string `json:"ends_at"`
+	}
+)
+
+const listVideosPageLimit = 100
+
+// MarshalJSON nil time is marshalled as null to clear it
+func (s *VideoSchedule) MarshalJSON() ([]byte, error) {
+	v := new(videoScheduleJSON)
+	if s.StartsAt != nil {
+		startsAt := s.StartsAt.UTC().Format(timestampFormat)
+		v.StartsAt = &startsAt
+	}
+	if s.EndsAt != nil {
+		endsAt := s.EndsAt.UTC().Format(timestampFormat)
+		v.EndsAt = &endsAt
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON :nodoc:
+func (s *VideoSchedule) UnmarshalJSON(b []byte) error {
+	v := new(videoScheduleJSON)
+	err := json.Unmarshal(b, v)
+	if err != nil {
+		return err
+	}
+
+	s.StartsAt, err = parseScheduleTime(v.StartsAt)
+	if err != nil {
+		return err
+	}
+	s.EndsAt, err = parseScheduleTime(v.EndsAt)
+	return err
+}
+
+func parseScheduleTime(s *string) (*time.Time, error) {
+	if s == nil || *s == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, *s)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// ListScheduledVideos list videos which go live or expire between from and to
+func (c *client) ListScheduledVideos(from, to time.Time) (*ScheduledVideos, error) {
+	window := fmt.Sprintf("%s..%s", from.UTC().Format(time.RFC3339), to.UTC().Format(time.RFC3339))
+	query := &VideoQuery{
+		// terms without + are ORed, a video either goes live or expires within the window
+		Query: fmt.Sprintf("schedule.starts_at:%s schedule.ends_at:%s", window, window),
+		Sort:  "schedule.starts_at",
+		Limit: listVideosPageLimit,
+	}
+
+	scheduled := new(ScheduledVideos)
+	for {
+		videos, err := c.ListVideos(query)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"from": from,
+				"to":   to}).
+				Error(err)
+			return nil, err
+		}
+
+		for _, v := range videos {
+			if v.Schedule == nil {
+				continue
+			}
+			if inWindow(v.Schedule.StartsAt, from, to) {
+				scheduled.GoingLive = append(scheduled.GoingLive, v)
+			}
+			if inWindow(v.Schedule.EndsAt, from, to) {
+				scheduled.Expiring = append(scheduled.Expiring, v)
+			}
+		}
+
+		if len(videos) < query.Limit {
+			return scheduled, nil
+		}
+		query.Offset += len(videos)
+	}
+}
+
+func inWindow(t *time.Time, from, to time.Time) bool {
+	return t != nil && !t.Before(from) && !t.After(to)
+}
@@ -0,0 +1,90 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoSchedule_JSON(t *testing.T) {
+	startsAt := time.Date(2019, 5, 1, 17, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+
+	b, err := json.Marshal(&VideoSchedule{StartsAt: &startsAt})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"starts_at": "2019-05-01T10:00:00.000Z", "ends_at": null}`, string(b))
+
+	schedule := new(VideoSchedule)
+	assert.NoError(t, json.Unmarshal([]byte(`{"starts_at": "2019-05-01T10:00:00.000Z", "ends_at": "2019-06-01T10:00:00.548Z"}`), schedule))
+	assert.True(t, startsAt.Equal(*schedule.StartsAt))
+	assert.Equal(t, 548*time.Millisecond, time.Duration(schedule.EndsAt.Nanosecond()))
+
+	schedule = new(VideoSchedule)
+	assert.NoError(t, json.Unmarshal([]byte(`{"starts_at": null, "ends_at": null}`), schedule))
+	assert.Nil(t, schedule.StartsAt)
+	assert.Nil(t, schedule.EndsAt)
+}
+
+func TestClient_UpdateVideo_Schedule(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		assert.JSONEq(t, `{"schedule": {"starts_at": null, "ends_at": null}}`, string(b))
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "12345", "schedule": null}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	video, err := bh.UpdateVideo("12345", &UpdateVideoRequest{Schedule: &VideoSchedule{}})
+	assert.NoError(t, err)
+	assert.Nil(t, video.Schedule)
+}
+
+func TestClient_ListScheduledVideos(t *testing.T) {
+	from := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+
+	var offsets []string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "schedule.starts_at:2019-05-01T00:00:00Z..2019-05-02T00:00:00Z schedule.ends_at:2019-05-01T00:00:00Z..2019-05-02T00:00:00Z", r.URL.Query().Get("q"))
+		offsets = append(offsets, r.URL.Query().Get("offset"))
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+
+		w.WriteHeader(http.StatusOK)
+		if offset > 0 {
+			io.WriteString(w, `[{"id": "expiring", "schedule": {"starts_at": "2019-04-01T00:00:00.000Z", "ends_at": "2019-05-01T12:00:00.000Z"}}]`)
+			return
+		}
+		// first page is full, so the next page is requested
+		io.WriteString(w, "[")
+		for i := 0; i < listVideosPageLimit; i++ {
+			if i > 0 {
+				io.WriteString(w, ",")
+			}
+			fmt.Fprintf(w, `{"id": "live-%d", "schedule": {"starts_at": "2019-05-01T06:00:00.000Z", "ends_at": null}}`, i)
+		}
+		io.WriteString(w, "]")
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	scheduled, err := bh.ListScheduledVideos(from, to)
+	assert.NoError(t, err)
+	assert.Equal(t, listVideosPageLimit, len(scheduled.GoingLive))
+	assert.Equal(t, 1, len(scheduled.Expiring))
+	assert.Equal(t, "expiring", scheduled.Expiring[0].ID)
+	assert.Equal(t, []string{"", "100"}, offsets)
+}
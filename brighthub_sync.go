@@ -0,0 +1,300 @@
+package brighthub
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// VideoSyncer copy videos of Source account into Target account, videos are matched by reference id.
+	// Metadata, custom fields, cue points, schedule, geo, folder and text tracks are copied.
+	// Target account must define the same custom fields.
+	VideoSyncer struct {
+		Source Client
+		Target Client
+		// DryRun only report what would be changed
+		DryRun bool
+		// Profile ingest profile of the target, leave empty to use the target account default
+		Profile string
+		// Reingest ingest target videos which already exist again, by default only created videos are ingested
+		Reingest bool
+		// SourceURL required, where the target ingests the master of the source video from, e.g. the storage
+		// the source account ingested it from. Archived masters can't be read from another account, and renditions
+		// aren't used since they are transcoded and their urls may expire.
+		SourceURL func(video *Video) (string, error)
+
+		sourceFolders map[string]string
+		targetFolders map[string]string
+	}
+
+	// SyncAction :nodoc:
+	SyncAction string
+
+	// SyncItem diff of a source video and its target video
+	SyncItem struct {
+		SourceVideoID string
+		TargetVideoID string
+		ReferenceID   string
+		Action        SyncAction
+		// Changed JSON names of the changed fields, plus folder, text_tracks and ingest
+		Changed []string
+		Err     error
+	}
+
+	// SyncReport :nodoc:
+	SyncReport struct {
+		DryRun bool
+		Items  []*SyncItem
+	}
+)
+
+const (
+	// SyncActionCreate :nodoc:
+	SyncActionCreate SyncAction = "create"
+	// SyncActionUpdate :nodoc:
+	SyncActionUpdate SyncAction = "update"
+	// SyncActionUnchanged :nodoc:
+	SyncActionUnchanged SyncAction = "unchanged"
+	// SyncActionFailed :nodoc:
+	SyncActionFailed SyncAction = "failed"
+)
+
+// Sync every source video matching the CMS search query, e.g. "tags:staging" or "id:12345".
+// Failed videos don't stop the others, their error is in the report.
+func (s *VideoSyncer) Sync(query string) (*SyncReport, error) {
+	if s.SourceURL == nil {
+		return nil, ErrSourceURLRequired
+	}
+
+	err := s.loadFolders()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SyncReport{DryRun: s.DryRun}
+	q := &VideoQuery{Query: query, Sort: "created_at", Limit: listVideosPageLimit}
+	for {
+		videos, err := s.Source.ListVideos(q)
+		if err != nil {
+			log.WithFields(log.Fields{
+				"query":  query,
+				"offset": q.Offset}).
+				Error(err)
+			return report, err
+		}
+
+		for _, v := range videos {
+			item := s.syncVideo(v)
+			if item.Err != nil {
+				log.WithFields(log.Fields{
+					"videoID": v.ID}).
+					Error(item.Err)
+				item.Action = SyncActionFailed
+			}
+			report.Items = append(report.Items, item)
+		}
+
+		if len(videos) < q.Limit {
+			return report, nil
+		}
+		q.Offset += len(videos)
+	}
+}
+
+// loadFolders folder ids differ between accounts, so folders are matched by name
+func (s *VideoSyncer) loadFolders() error {
+	s.sourceFolders = map[string]string{}
+	s.targetFolders = map[string]string{}
+
+	folders, err := s.Source.ListFolders()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	for _, f := range folders {
+		s.sourceFolders[f.ID] = f.Name
+	}
+
+	folders, err = s.Target.ListFolders()
+	if err != nil {
+		log.Error(err)
+		return err
+	}
+	for _, f := range folders {
+		s.targetFolders[f.Name] = f.ID
+	}
+	return nil
+}
+
+func (s *VideoSyncer) syncVideo(v *Video) *SyncItem {
+	referenceID := v.ReferenceID
+	if referenceID == "" {
+		referenceID = fmt.Sprintf("sync-%s-%s", v.AccountID, v.ID)
+	}
+	item := &SyncItem{SourceVideoID: v.ID, ReferenceID: referenceID}
+
+	var cuePoints []*CuePoint
+	for _, cp := range v.CuePoints {
+		c := *cp
+		c.ID = ""
+		cuePoints = append(cuePoints, &c)
+	}
+	desired := &CreateVideoRequest{
+		Name:            v.Name,
+		Description:     v.Description,
+		LongDescription: v.LongDescription,
+		ReferenceID:     referenceID,
+		State:           v.State,
+		Tags:            v.Tags,
+		CustomFields:    v.CustomFields,
+		CuePoints:       cuePoints,
+		Schedule:        v.Schedule,
+		Geo:             v.Geo,
+	}
+
+	target, err := s.Target.GetVideoByReferenceID(referenceID)
+	if err != nil && err != ErrResourceNotFound {
+		item.Err = err
+		return item
+	}
+
+	var update *UpdateVideoRequest
+	var textTracks []*IngestTextTrack
+	folderName := s.sourceFolders[v.FolderID]
+	if target == nil {
+		item.Action = SyncActionCreate
+		item.Changed = append(item.Changed, "ingest")
+		if folderName != "" {
+			item.Changed = append(item.Changed, "folder")
+		}
+		textTracks = missingTextTracks(v.TextTracks, nil)
+	} else {
+		item.TargetVideoID = target.ID
+		update, item.Changed = diffVideo(target, desired)
+		if len(item.Changed) == 0 {
+			update = nil
+		}
+		if folderName != "" && s.targetFolders[folderName] != target.FolderID {
+			item.Changed = append(item.Changed, "folder")
+		}
+		textTracks = missingTextTracks(v.TextTracks, target.TextTracks)
+		if s.Reingest {
+			item.Changed = append(item.Changed, "ingest")
+		}
+	}
+	if len(textTracks) > 0 {
+		item.Changed = append(item.Changed, "text_tracks")
+	}
+	if item.Action == "" {
+		item.Action = SyncActionUnchanged
+		if len(item.Changed) > 0 {
+			item.Action = SyncActionUpdate
+		}
+	}
+	if s.DryRun || item.Action == SyncActionUnchanged {
+		return item
+	}
+
+	item.Err = s.apply(v, item, desired, update, textTracks, folderName)
+	return item
+}
+
+func (s *VideoSyncer) apply(v *Video, item *SyncItem, desired *CreateVideoRequest, update *UpdateVideoRequest, textTracks []*IngestTextTrack, folderName string) error {
+	changed := map[string]bool{}
+	for _, c := range item.Changed {
+		changed[c] = true
+	}
+
+	if item.Action == SyncActionCreate {
+		created, err := s.Target.CreateVideo(desired)
+		if err != nil {
+			return err
+		}
+		item.TargetVideoID = created.ID
+	} else if update != nil {
+		_, err := s.Target.UpdateVideo(item.TargetVideoID, update)
+		if err != nil {
+			return err
+		}
+	}
+
+	if changed["folder"] {
+		folderID, ok := s.targetFolders[folderName]
+		if !ok {
+			folder, err := s.Target.CreateFolder(folderName)
+			if err != nil {
+				return err
+			}
+			folderID = folder.ID
+			s.targetFolders[folderName] = folderID
+		}
+		err := s.Target.AddVideoToFolder(item.TargetVideoID, folderID)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !changed["ingest"] && len(textTracks) == 0 {
+		return nil
+	}
+	req := &IngestVideoRequest{
+		Priority:      PriorityNormal,
+		CaptureImages: changed["ingest"],
+		Profile:       s.Profile,
+		TextTracks:    textTracks,
+	}
+	if changed["ingest"] {
+		sourceURL, err := s.SourceURL(v)
+		if err != nil {
+			return err
+		}
+		req.Master = &IngestVideoMaster{URL: sourceURL}
+	}
+	_, err := s.Target.IngestVideo(item.TargetVideoID, req)
+	return err
+}
+
+// missingTextTracks source text tracks which the target doesn't have, matched by language, kind and label
+func missingTextTracks(source, target []*TextTrack) []*IngestTextTrack {
+	existing := map[string]bool{}
+	for _, t := range target {
+		existing[t.Srclang+"|"+t.Kind+"|"+t.Label] = true
+	}
+
+	var missing []*IngestTextTrack
+	for _, t := range source {
+		if existing[t.Srclang+"|"+t.Kind+"|"+t.Label] {
+			continue
+		}
+		missing = append(missing, &IngestTextTrack{
+			URL:     t.Src,
+			Srclang: t.Srclang,
+			Kind:    t.Kind,
+			Label:   t.Label,
+			Default: t.Default,
+		})
+	}
+	return missing
+}
+
+// Write diff report as table, a line per video
+func (r *SyncReport) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+	if r.DryRun {
+		fmt.Fprintln(tw, "DRY RUN, nothing was changed")
+	}
+	fmt.Fprintln(tw, "ACTION\tSOURCE\tTARGET\tREFERENCE_ID\tCHANGED\tERROR")
+	for _, item := range r.Items {
+		errMessage := ""
+		if item.Err != nil {
+			errMessage = item.Err.Error()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", item.Action, item.SourceVideoID, item.TargetVideoID,
+			item.ReferenceID, strings.Join(item.Changed, ","), errMessage)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,87 @@
+package brighthub_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/kumparan/brighthub/brighthubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVideoSyncer_Sync(t *testing.T) {
+	brighthub.UseRealBaseURLs()
+	staging := brighthubtest.NewServer("staging", "client-id", "client-secret")
+	defer staging.Close()
+	production := brighthubtest.NewServer("production", "client-id", "client-secret")
+	defer production.Close()
+
+	source, err := staging.NewClient()
+	assert.NoError(t, err)
+	target, err := production.NewClient()
+	assert.NoError(t, err)
+
+	staging.AddFolder("Berita")
+	created, err := source.CreateVideo(&brighthub.CreateVideoRequest{
+		Name:         "Banjir Jakarta",
+		ReferenceID:  "story-1",
+		Tags:         []string{"news"},
+		CustomFields: map[string]string{"author": "Ahmad"},
+	})
+	assert.NoError(t, err)
+	_, err = source.IngestVideo(created.ID, &brighthub.IngestVideoRequest{
+		Master:     &brighthub.IngestVideoMaster{URL: "https://kumparan.com/banjir.mp4"},
+		TextTracks: []*brighthub.IngestTextTrack{{URL: "https://kumparan.com/banjir.vtt", Srclang: "id", Kind: "captions"}},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, source.AddVideoToFolder(created.ID, "Berita"))
+	staging.WaitIngestJobs()
+
+	syncer := &brighthub.VideoSyncer{Source: source, Target: target, DryRun: true}
+	_, err = syncer.Sync("tags:news")
+	assert.Equal(t, brighthub.ErrSourceURLRequired, err)
+
+	syncer.SourceURL = func(video *brighthub.Video) (string, error) {
+		return "https://kumparan.com/" + video.ReferenceID + ".mp4", nil
+	}
+	report, err := syncer.Sync("tags:news")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(report.Items))
+	assert.Equal(t, brighthub.SyncActionCreate, report.Items[0].Action)
+	assert.Equal(t, []string{"ingest", "folder", "text_tracks"}, report.Items[0].Changed)
+	assert.Empty(t, production.IngestJobs())
+
+	out := new(bytes.Buffer)
+	assert.NoError(t, report.Write(out))
+	assert.Contains(t, out.String(), "DRY RUN")
+	assert.Contains(t, out.String(), "story-1")
+
+	syncer.DryRun = false
+	report, err = syncer.Sync("tags:news")
+	assert.NoError(t, err)
+	assert.NoError(t, report.Items[0].Err)
+	production.WaitIngestJobs()
+
+	synced := production.Video(report.Items[0].TargetVideoID)
+	assert.Equal(t, "Banjir Jakarta", synced.Name)
+	assert.Equal(t, "Ahmad", synced.CustomFields["author"])
+	assert.Equal(t, 1, len(synced.TextTracks))
+	assert.Equal(t, []string{synced.ID}, production.FolderVideos(synced.FolderID))
+	jobs := production.IngestJobs()
+	assert.Equal(t, 1, len(jobs))
+	assert.Equal(t, "https://kumparan.com/story-1.mp4", jobs[0].Request.Master.URL)
+
+	report, err = syncer.Sync("tags:news")
+	assert.NoError(t, err)
+	assert.Equal(t, brighthub.SyncActionUnchanged, report.Items[0].Action)
+
+	description := "banjir surut"
+	_, err = source.UpdateVideo(created.ID, &brighthub.UpdateVideoRequest{Description: &description})
+	assert.NoError(t, err)
+	report, err = syncer.Sync("tags:news")
+	assert.NoError(t, err)
+	assert.Equal(t, brighthub.SyncActionUpdate, report.Items[0].Action)
+	assert.Equal(t, []string{"description"}, report.Items[0].Changed)
+	assert.Equal(t, description, production.Video(synced.ID).Description)
+	assert.Equal(t, 1, len(production.IngestJobs()))
+}
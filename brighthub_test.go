@@ -1,6 +1,7 @@
 package brighthub
 
 import (
+	"context"
 	"io"
 	"net/http"
 	"net/http/httptest"
@@ -14,12 +15,14 @@ import (
 
 func newClientMock() *client {
 	return &client{
-		accountID:             fake.Characters(),
-		clientID:              fake.Characters(),
-		clientSecret:          fake.Characters(),
-		accessToken:           fake.CharactersN(20),
-		accessTokenAcquiredAt: time.Now(),
-		httpClient:            defaultHTTPClient,
+		accountID:    fake.Characters(),
+		clientID:     fake.Characters(),
+		clientSecret: fake.Characters(),
+		accessTokenCache: &accessTokenCache{
+			accessToken:           fake.CharactersN(20),
+			accessTokenAcquiredAt: time.Now(),
+		},
+		httpClient: defaultHTTPClient,
 	}
 }
 
@@ -80,3 +83,35 @@ func TestClient_getAccessToken(t *testing.T) {
 		assert.True(t, time.Since(bhc.accessTokenAcquiredAt).Minutes() < 1)
 	})
 }
+
+func TestClient_AccessToken(t *testing.T) {
+	bh := newClientMock()
+	token, err := bh.AccessToken()
+	assert.NoError(t, err)
+	assert.Equal(t, bh.accessToken, token)
+}
+
+func TestClient_WithContext(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{"id": "1"}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	withCtx := bh.WithContext(ctx)
+	assert.Equal(t, bh.accessTokenCache, withCtx.(*client).accessTokenCache)
+
+	_, err := withCtx.GetVideo("1")
+	assert.NoError(t, err)
+
+	cancel()
+	_, err = withCtx.GetVideo("1")
+	assert.Error(t, err)
+	_, err = bh.GetVideo("1")
+	assert.NoError(t, err)
+}
@@ -0,0 +1,162 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Response timestamps and durations use these types instead of the raw string and int64 Brightcove returns,
+// the raw value is still available as Timestamp.String, UnixTimestamp.UnixMilliseconds, Duration.Milliseconds
+// and SecondsDuration.Seconds, and ParseTimestamp build a Timestamp from the raw string.
+type (
+	// Timestamp Brightcove ISO 8601 timestamp, the zero value is marshalled as null
+	Timestamp struct {
+		time.Time
+		// raw timestamp as returned by Brightcove, kept so String returns it unchanged
+		raw string
+	}
+
+	// UnixTimestamp Brightcove epoch timestamp in milliseconds, used by the ingest profiles and Live APIs,
+	// the zero value is marshalled as null
+	UnixTimestamp struct {
+		time.Time
+	}
+
+	// Duration Brightcove duration, which is in milliseconds
+	Duration struct {
+		time.Duration
+	}
+
+	// SecondsDuration Brightcove duration in seconds, used by the Live and Analytics APIs
+	SecondsDuration struct {
+		time.Duration
+	}
+)
+
+// timestampFormat Brightcove returns UTC with millisecond precision
+const timestampFormat = "2006-01-02T15:04:05.000Z07:00"
+
+// String timestamp as it was returned by Brightcove, offset and precision included, empty when zero.
+// Timestamps not parsed from Brightcove or whose Time has been changed are formatted in UTC with milliseconds.
+func (t Timestamp) String() string {
+	if t.IsZero() {
+		return ""
+	}
+	if t.raw != "" {
+		if parsed, err := time.Parse(time.RFC3339Nano, t.raw); err == nil && parsed.Equal(t.Time) {
+			return t.raw
+		}
+	}
+	return t.UTC().Format(timestampFormat)
+}
+
+// ParseTimestamp parse timestamp in Brightcove format, empty string is the zero timestamp
+func ParseTimestamp(s string) (Timestamp, error) {
+	if s == "" {
+		return Timestamp{}, nil
+	}
+	t, err := time.Parse(time.RFC3339Nano, s)
+	if err != nil {
+		return Timestamp{}, err
+	}
+	return Timestamp{Time: t, raw: s}, nil
+}
+
+// MarshalJSON :nodoc:
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.String())
+}
+
+// UnmarshalJSON :nodoc:
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	var s *string
+	err := json.Unmarshal(b, &s)
+	if err != nil {
+		return err
+	}
+	if s == nil {
+		t.Time = time.Time{}
+		return nil
+	}
+	*t, err = ParseTimestamp(*s)
+	return err
+}
+
+// UnixMilliseconds milliseconds since epoch, zero when the timestamp is zero, as it was returned before UnixTimestamp
+func (t UnixTimestamp) UnixMilliseconds() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// MarshalJSON :nodoc:
+func (t UnixTimestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.UnixMilliseconds())
+}
+
+// UnmarshalJSON :nodoc:
+func (t *UnixTimestamp) UnmarshalJSON(b []byte) error {
+	var ms *int64
+	err := json.Unmarshal(b, &ms)
+	if err != nil {
+		return err
+	}
+	if ms == nil || *ms == 0 {
+		t.Time = time.Time{}
+		return nil
+	}
+	t.Time = time.Unix(0, *ms*int64(time.Millisecond)).UTC()
+	return nil
+}
+
+// Milliseconds duration in milliseconds, as it was returned before Duration
+func (d Duration) Milliseconds() int64 {
+	return int64(d.Duration / time.Millisecond)
+}
+
+// MarshalJSON :nodoc:
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Milliseconds())
+}
+
+// UnmarshalJSON :nodoc:
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var ms *float64
+	err := json.Unmarshal(b, &ms)
+	if err != nil {
+		return err
+	}
+	if ms == nil {
+		d.Duration = 0
+		return nil
+	}
+	d.Duration = time.Duration(*ms * float64(time.Millisecond))
+	return nil
+}
+
+// MarshalJSON :nodoc:
+func (d SecondsDuration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.Seconds())
+}
+
+// UnmarshalJSON :nodoc:
+func (d *SecondsDuration) UnmarshalJSON(b []byte) error {
+	var seconds *float64
+	err := json.Unmarshal(b, &seconds)
+	if err != nil {
+		return err
+	}
+	if seconds == nil {
+		d.Duration = 0
+		return nil
+	}
+	d.Duration = time.Duration(*seconds * float64(time.Second))
+	return nil
+}
@@ -0,0 +1,125 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestamp_JSON(t *testing.T) {
+	ts := new(Timestamp)
+	assert.NoError(t, json.Unmarshal([]byte(`"2019-04-30T10:09:12.548Z"`), ts))
+	assert.True(t, time.Date(2019, 4, 30, 10, 9, 12, 548000000, time.UTC).Equal(ts.Time))
+	assert.Equal(t, "2019-04-30T10:09:12.548Z", ts.String())
+
+	b, err := json.Marshal(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2019-04-30T10:09:12.548Z"`, string(b))
+
+	ts = new(Timestamp)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), ts))
+	assert.True(t, ts.IsZero())
+	assert.Equal(t, "", ts.String())
+
+	b, err = json.Marshal(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`"30/04/2019"`), ts))
+}
+
+func TestDuration_JSON(t *testing.T) {
+	d := new(Duration)
+	assert.NoError(t, json.Unmarshal([]byte(`31431`), d))
+	assert.Equal(t, 31431*time.Millisecond, d.Duration)
+	assert.Equal(t, int64(31431), d.Milliseconds())
+
+	b, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `31431`, string(b))
+
+	d = new(Duration)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), d))
+	assert.Equal(t, time.Duration(0), d.Duration)
+}
+
+func TestVideo_JSON(t *testing.T) {
+	raw := `{"id": "12345", "duration": 65000, "created_at": "2019-04-30T10:09:12.548Z", "updated_at": "2019-05-01T00:00:00.000Z", "published_at": null}`
+
+	video := new(Video)
+	assert.NoError(t, json.Unmarshal([]byte(raw), video))
+	assert.Equal(t, 65*time.Second, video.Duration.Duration)
+	assert.Equal(t, "2019-04-30T10:09:12.548Z", video.CreatedAt.String())
+	assert.True(t, video.PublishedAt.IsZero())
+	assert.True(t, video.UpdatedAt.After(video.CreatedAt.Time))
+}
+
+func TestParseTimestamp(t *testing.T) {
+	ts, err := ParseTimestamp("2019-04-30T10:09:12.548Z")
+	assert.NoError(t, err)
+	assert.Equal(t, "2019-04-30T10:09:12.548Z", ts.String())
+
+	ts, err = ParseTimestamp("")
+	assert.NoError(t, err)
+	assert.True(t, ts.IsZero())
+
+	_, err = ParseTimestamp("30/04/2019")
+	assert.Error(t, err)
+}
+
+func TestTimestamp_StringKeepsRaw(t *testing.T) {
+	ts := new(Timestamp)
+	assert.NoError(t, json.Unmarshal([]byte(`"2019-04-30T17:09:12.548123+07:00"`), ts))
+	assert.Equal(t, "2019-04-30T17:09:12.548123+07:00", ts.String())
+	assert.True(t, time.Date(2019, 4, 30, 10, 9, 12, 548123000, time.UTC).Equal(ts.Time))
+
+	b, err := json.Marshal(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2019-04-30T17:09:12.548123+07:00"`, string(b))
+
+	// changed time no longer matches the raw timestamp
+	ts.Time = ts.Add(time.Hour)
+	assert.Equal(t, "2019-04-30T11:09:12.548Z", ts.String())
+
+	assert.Equal(t, "2019-04-30T10:09:12.548Z", Timestamp{Time: time.Date(2019, 4, 30, 10, 9, 12, 548000000, time.UTC)}.String())
+}
+
+func TestUnixTimestamp_JSON(t *testing.T) {
+	ts := new(UnixTimestamp)
+	assert.NoError(t, json.Unmarshal([]byte(`1556618952548`), ts))
+	assert.True(t, time.Date(2019, 4, 30, 10, 9, 12, 548000000, time.UTC).Equal(ts.Time))
+	assert.Equal(t, int64(1556618952548), ts.UnixMilliseconds())
+
+	b, err := json.Marshal(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, `1556618952548`, string(b))
+
+	ts = new(UnixTimestamp)
+	assert.NoError(t, json.Unmarshal([]byte(`null`), ts))
+	assert.True(t, ts.IsZero())
+	assert.Equal(t, int64(0), ts.UnixMilliseconds())
+
+	b, err = json.Marshal(ts)
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(b))
+
+	assert.Error(t, json.Unmarshal([]byte(`"2019-04-30"`), ts))
+}
+
+func TestSecondsDuration_JSON(t *testing.T) {
+	d := new(SecondsDuration)
+	assert.NoError(t, json.Unmarshal([]byte(`31.5`), d))
+	assert.Equal(t, 31500*time.Millisecond, d.Duration)
+
+	b, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `31.5`, string(b))
+
+	assert.NoError(t, json.Unmarshal([]byte(`1800`), d))
+	assert.Equal(t, 30*time.Minute, d.Duration)
+	b, err = json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `1800`, string(b))
+}
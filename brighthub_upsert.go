@@ -0,0 +1,185 @@
+package brighthub
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/kumparan/go-lib/utils"
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// UpsertAction :nodoc:
+	UpsertAction string
+
+	// UpsertResult :nodoc:
+	UpsertResult struct {
+		VideoID string
+		Action  UpsertAction
+		// Changed JSON names of the updated fields, empty unless Action is UpsertActionUpdated
+		Changed []string
+	}
+)
+
+const (
+	// UpsertActionCreated :nodoc:
+	UpsertActionCreated UpsertAction = "created"
+	// UpsertActionUpdated :nodoc:
+	UpsertActionUpdated UpsertAction = "updated"
+	// UpsertActionUnchanged :nodoc:
+	UpsertActionUnchanged UpsertAction = "unchanged"
+)
+
+// UpsertVideo create the video, or update the video with the same reference id when it already exists.
+// Only fields which differ from the existing video are updated, Description and LongDescription are
+// always compared while the other fields are compared only when set. It is safe to call repeatedly.
+func (c *client) UpsertVideo(req *CreateVideoRequest) (*UpsertResult, error) {
+	if req.ReferenceID == "" {
+		return nil, ErrReferenceIDRequired
+	}
+
+	created, err := c.CreateVideo(req)
+	if err == nil {
+		return &UpsertResult{VideoID: created.ID, Action: UpsertActionCreated}, nil
+	}
+	if err != ErrDuplicateReferenceID {
+		return nil, err
+	}
+
+	video, err := c.GetVideoByReferenceID(req.ReferenceID)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+
+	update, changed := diffVideo(video, req)
+	if len(changed) == 0 {
+		return &UpsertResult{VideoID: video.ID, Action: UpsertActionUnchanged}, nil
+	}
+
+	_, err = c.UpdateVideo(video.ID, update)
+	if err != nil {
+		log.WithFields(log.Fields{
+			"videoID": video.ID,
+			"request": utils.Dump(req)}).
+			Error(err)
+		return nil, err
+	}
+	return &UpsertResult{VideoID: video.ID, Action: UpsertActionUpdated, Changed: changed}, nil
+}
+
+// diffVideo update request of the fields which differ, and their JSON names
+func diffVideo(v *Video, req *CreateVideoRequest) (*UpdateVideoRequest, []string) {
+	update := new(UpdateVideoRequest)
+	var changed []string
+
+	if req.Name != "" && req.Name != v.Name {
+		update.Name = req.Name
+		changed = append(changed, "name")
+	}
+	if req.Description != v.Description {
+		update.Description = &req.Description
+		changed = append(changed, "description")
+	}
+	if req.LongDescription != v.LongDescription {
+		update.LongDescription = &req.LongDescription
+		changed = append(changed, "long_description")
+	}
+	if req.State != "" && req.State != v.State {
+		update.State = req.State
+		changed = append(changed, "state")
+	}
+	if req.Tags != nil && !sameTags(req.Tags, v.Tags) {
+		update.Tags = req.Tags
+		changed = append(changed, "tags")
+	}
+
+	// custom fields not in the request are kept as is
+	for k, value := range req.CustomFields {
+		if v.CustomFields[k] == value {
+			continue
+		}
+		if update.CustomFields == nil {
+			update.CustomFields = map[string]string{}
+			changed = append(changed, "custom_fields")
+		}
+		update.CustomFields[k] = value
+	}
+
+	if req.CuePoints != nil && !sameCuePoints(req.CuePoints, v.CuePoints) {
+		update.CuePoints = req.CuePoints
+		changed = append(changed, "cue_points")
+	}
+	if req.Schedule != nil && !sameSchedule(req.Schedule, v.Schedule) {
+		update.Schedule = req.Schedule
+		changed = append(changed, "schedule")
+	}
+	if req.Geo != nil && !sameGeo(req.Geo, v.Geo) {
+		update.Geo = req.Geo
+		changed = append(changed, "geo")
+	}
+	return update, changed
+}
+
+// sameTags tags order doesn't matter
+func sameTags(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append([]string{}, a...)
+	y := append([]string{}, b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	return reflect.DeepEqual(x, y)
+}
+
+// sameCuePoints cue point ids are assigned by Brightcove, so they are ignored
+func sameCuePoints(a, b []*CuePoint) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		x, y := *a[i], *b[i]
+		x.ID, y.ID = "", ""
+		if x != y {
+			return false
+		}
+	}
+	return true
+}
+
+func sameSchedule(a, b *VideoSchedule) bool {
+	if b == nil {
+		return a.StartsAt == nil && a.EndsAt == nil
+	}
+	return sameTime(a.StartsAt, b.StartsAt) && sameTime(a.EndsAt, b.EndsAt)
+}
+
+// sameTime Brightcove keeps millisecond precision
+func sameTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Truncate(time.Millisecond).Equal(b.Truncate(time.Millisecond))
+}
+
+// sameGeo countries are case insensitive and their order doesn't matter
+func sameGeo(a, b *VideoGeo) bool {
+	if b == nil {
+		return !a.Restricted
+	}
+	if a.Restricted != b.Restricted || a.ExcludeCountries != b.ExcludeCountries || len(a.Countries) != len(b.Countries) {
+		return false
+	}
+	x := make([]string, len(a.Countries))
+	y := make([]string, len(b.Countries))
+	for i := range a.Countries {
+		x[i] = strings.ToLower(a.Countries[i])
+		y[i] = strings.ToLower(b.Countries[i])
+	}
+	return sameTags(x, y)
+}
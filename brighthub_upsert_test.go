@@ -0,0 +1,94 @@
+package brighthub
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_UpsertVideo(t *testing.T) {
+	var patched []string
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			b, _ := ioutil.ReadAll(r.Body)
+			if strings.Contains(string(b), `"reference_id":"story-1"`) {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id": "new-video"}`)
+		case r.Method == "GET":
+			assert.True(t, strings.HasSuffix(r.URL.Path, "/videos/ref:story-1"))
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{
+				"id": "12345",
+				"name": "Banjir Jakarta",
+				"description": "banjir",
+				"long_description": "",
+				"reference_id": "story-1",
+				"state": "ACTIVE",
+				"tags": ["news", "banjir"],
+				"custom_fields": {"author": "Ahmad"},
+				"cue_points": [{"id": "cp-1", "name": "Intro", "type": "CODE", "time": 0, "force_stop": false}],
+				"schedule": {"starts_at": "2019-05-01T10:00:00.000Z", "ends_at": null},
+				"geo": {"countries": ["id"], "exclude_countries": false, "restricted": true}
+			}`)
+		case r.Method == "PATCH":
+			b, _ := ioutil.ReadAll(r.Body)
+			patched = append(patched, string(b))
+			w.WriteHeader(http.StatusOK)
+			io.WriteString(w, `{"id": "12345"}`)
+		}
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	result, err := bh.UpsertVideo(&CreateVideoRequest{Name: "Baru", ReferenceID: "story-2"})
+	assert.NoError(t, err)
+	assert.Equal(t, &UpsertResult{VideoID: "new-video", Action: UpsertActionCreated}, result)
+
+	startsAt := time.Date(2019, 5, 1, 17, 0, 0, 0, time.FixedZone("WIB", 7*3600))
+	same := &CreateVideoRequest{
+		Name:         "Banjir Jakarta",
+		Description:  "banjir",
+		ReferenceID:  "story-1",
+		State:        StateActive,
+		Tags:         []string{"banjir", "news"},
+		CustomFields: map[string]string{"author": "Ahmad"},
+		CuePoints:    []*CuePoint{{Name: "Intro", Type: CuePointTypeCode}},
+		Schedule:     &VideoSchedule{StartsAt: &startsAt},
+		Geo:          &VideoGeo{Countries: []string{"ID"}, Restricted: true},
+	}
+	result, err = bh.UpsertVideo(same)
+	assert.NoError(t, err)
+	assert.Equal(t, &UpsertResult{VideoID: "12345", Action: UpsertActionUnchanged}, result)
+	assert.Empty(t, patched)
+
+	changed := *same
+	changed.Name = "Banjir Jakarta Surut"
+	changed.CustomFields = map[string]string{"author": "Ahmad", "editor": "Budi"}
+	changed.Geo = &VideoGeo{}
+	result, err = bh.UpsertVideo(&changed)
+	assert.NoError(t, err)
+	assert.Equal(t, UpsertActionUpdated, result.Action)
+	assert.Equal(t, []string{"name", "custom_fields", "geo"}, result.Changed)
+	assert.Equal(t, 1, len(patched))
+	assert.JSONEq(t, `{
+		"name": "Banjir Jakarta Surut",
+		"custom_fields": {"editor": "Budi"},
+		"geo": {"countries": null, "exclude_countries": false, "restricted": false}
+	}`, patched[0])
+
+	_, err = bh.UpsertVideo(&CreateVideoRequest{Name: "Tanpa Reference ID"})
+	assert.Equal(t, ErrReferenceIDRequired, err)
+}
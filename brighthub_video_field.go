@@ -0,0 +1,68 @@
+package brighthub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	log "github.com/sirupsen/logrus"
+)
+
+type (
+	// VideoFields custom and standard field definitions of the account
+	VideoFields struct {
+		MaxCustomFields int64         `json:"max_custom_fields"`
+		CustomFields    []*VideoField `json:"custom_fields"`
+		StandardFields  []*VideoField `json:"standard_fields"`
+	}
+
+	// VideoField :nodoc:
+	VideoField struct {
+		ID          string `json:"id"`
+		DisplayName string `json:"display_name"`
+		Description string `json:"description"`
+		// Type string or enum
+		Type       string   `json:"type"`
+		Required   bool     `json:"required"`
+		EnumValues []string `json:"enum_values,omitempty"`
+	}
+)
+
+// GetVideoFields :nodoc:
+func (c *client) GetVideoFields() (*VideoFields, error) {
+	r, err := c.newRequest("GET", fmt.Sprintf("%s/accounts/%s/video_fields", cmsBaseURL, c.accountID), nil)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+
+	resp, err := c.do(r)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return nil, ErrUnauthorized
+		case http.StatusForbidden:
+			return nil, ErrNotAvailable
+		case http.StatusTooManyRequests:
+			return nil, ErrTooManyRequest
+		case http.StatusInternalServerError:
+			return nil, ErrInternalError
+		default:
+			return nil, fmt.Errorf("undefined error with code %d", resp.StatusCode)
+		}
+	}
+
+	fields := new(VideoFields)
+	err = json.NewDecoder(resp.Body).Decode(&fields)
+	if err != nil {
+		log.Error(err)
+		return nil, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,33 @@
+package brighthub
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetVideoFields(t *testing.T) {
+	httpMock := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Contains(t, r.URL.Path, "/video_fields")
+		w.WriteHeader(http.StatusOK)
+		io.WriteString(w, `{
+			"max_custom_fields": 10,
+			"custom_fields": [{"id": "author", "display_name": "Author", "type": "string", "required": false}],
+			"standard_fields": [{"id": "name", "required": true}]
+		}`)
+	}))
+	defer httpMock.Close()
+	cmsBaseURL = httpMock.URL // change for test
+
+	bh := newClientMock()
+	bh.httpClient = httpMock.Client()
+
+	fields, err := bh.GetVideoFields()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), fields.MaxCustomFields)
+	assert.Equal(t, "author", fields.CustomFields[0].ID)
+	assert.True(t, fields.StandardFields[0].Required)
+}
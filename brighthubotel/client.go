@@ -0,0 +1,267 @@
+package brighthubotel
+
+import (
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+// AccessToken :nodoc:
+func (c *Client) AccessToken() (string, error) {
+	return c.next.WithContext(c.ctx).AccessToken()
+}
+
+// AddVideoToFolder :nodoc:
+func (c *Client) AddVideoToFolder(videoID, folderID string) error {
+	bh, span := c.start("AddVideoToFolder", AttributeVideoID.String(videoID))
+	err := bh.AddVideoToFolder(videoID, folderID)
+	endSpan(span, err)
+	return err
+}
+
+// ListFolders :nodoc:
+func (c *Client) ListFolders() ([]*brighthub.Folder, error) {
+	bh, span := c.start("ListFolders")
+	res, err := bh.ListFolders()
+	endSpan(span, err)
+	return res, err
+}
+
+// CreateFolder :nodoc:
+func (c *Client) CreateFolder(name string) (*brighthub.Folder, error) {
+	bh, span := c.start("CreateFolder")
+	res, err := bh.CreateFolder(name)
+	endSpan(span, err)
+	return res, err
+}
+
+// ListPlaylists :nodoc:
+func (c *Client) ListPlaylists(limit, offset int) ([]*brighthub.Playlist, error) {
+	bh, span := c.start("ListPlaylists")
+	res, err := bh.ListPlaylists(limit, offset)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetVideoFields :nodoc:
+func (c *Client) GetVideoFields() (*brighthub.VideoFields, error) {
+	bh, span := c.start("GetVideoFields")
+	res, err := bh.GetVideoFields()
+	endSpan(span, err)
+	return res, err
+}
+
+// CreateVideo :nodoc:
+func (c *Client) CreateVideo(req *brighthub.CreateVideoRequest) (*brighthub.CreateVideoResponse, error) {
+	bh, span := c.start("CreateVideo")
+	res, err := bh.CreateVideo(req)
+	if err == nil {
+		span.SetAttributes(AttributeVideoID.String(res.ID))
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// GetVideo :nodoc:
+func (c *Client) GetVideo(videoID string) (*brighthub.Video, error) {
+	bh, span := c.start("GetVideo", AttributeVideoID.String(videoID))
+	res, err := bh.GetVideo(videoID)
+	endSpan(span, err)
+	return res, err
+}
+
+// ListVideos :nodoc:
+func (c *Client) ListVideos(query *brighthub.VideoQuery) ([]*brighthub.Video, error) {
+	bh, span := c.start("ListVideos")
+	res, err := bh.ListVideos(query)
+	endSpan(span, err)
+	return res, err
+}
+
+// ListScheduledVideos :nodoc:
+func (c *Client) ListScheduledVideos(from, to time.Time) (*brighthub.ScheduledVideos, error) {
+	bh, span := c.start("ListScheduledVideos")
+	res, err := bh.ListScheduledVideos(from, to)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetVideoByReferenceID :nodoc:
+func (c *Client) GetVideoByReferenceID(referenceID string) (*brighthub.Video, error) {
+	bh, span := c.start("GetVideoByReferenceID", AttributeReferenceID.String(referenceID))
+	res, err := bh.GetVideoByReferenceID(referenceID)
+	if err == nil {
+		span.SetAttributes(AttributeVideoID.String(res.ID))
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// UpdateVideo :nodoc:
+func (c *Client) UpdateVideo(videoID string, req *brighthub.UpdateVideoRequest) (*brighthub.Video, error) {
+	bh, span := c.start("UpdateVideo", AttributeVideoID.String(videoID))
+	res, err := bh.UpdateVideo(videoID, req)
+	endSpan(span, err)
+	return res, err
+}
+
+// UpsertVideo :nodoc:
+func (c *Client) UpsertVideo(req *brighthub.CreateVideoRequest) (*brighthub.UpsertResult, error) {
+	bh, span := c.start("UpsertVideo", AttributeReferenceID.String(req.ReferenceID))
+	res, err := bh.UpsertVideo(req)
+	if err == nil {
+		span.SetAttributes(AttributeVideoID.String(res.VideoID))
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// ReplaceCuePoints :nodoc:
+func (c *Client) ReplaceCuePoints(videoID string, cuePoints []*brighthub.CuePoint) (*brighthub.Video, error) {
+	bh, span := c.start("ReplaceCuePoints", AttributeVideoID.String(videoID))
+	res, err := bh.ReplaceCuePoints(videoID, cuePoints)
+	endSpan(span, err)
+	return res, err
+}
+
+// ApplyGeoRestriction :nodoc:
+func (c *Client) ApplyGeoRestriction(query string, geo *brighthub.VideoGeo) (*brighthub.BulkGeoResult, error) {
+	bh, span := c.start("ApplyGeoRestriction")
+	res, err := bh.ApplyGeoRestriction(query, geo)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetIngestProfile :nodoc:
+func (c *Client) GetIngestProfile(id string) (*brighthub.IngestProfile, error) {
+	bh, span := c.start("GetIngestProfile")
+	res, err := bh.GetIngestProfile(id)
+	endSpan(span, err)
+	return res, err
+}
+
+// IngestVideo :nodoc:
+func (c *Client) IngestVideo(videoID string, req *brighthub.IngestVideoRequest) (*brighthub.IngestVideoResponse, error) {
+	bh, span := c.start("IngestVideo", AttributeVideoID.String(videoID))
+	res, err := bh.IngestVideo(videoID, req)
+	if err == nil {
+		span.SetAttributes(AttributeJobID.String(res.ID))
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// PreflightIngestVideo :nodoc:
+func (c *Client) PreflightIngestVideo(req *brighthub.IngestVideoRequest) (*brighthub.IngestPreflightReport, error) {
+	bh, span := c.start("PreflightIngestVideo")
+	res, err := bh.PreflightIngestVideo(req)
+	endSpan(span, err)
+	return res, err
+}
+
+// Retranscode :nodoc:
+func (c *Client) Retranscode(videoID, profile string) (*brighthub.IngestVideoResponse, error) {
+	bh, span := c.start("Retranscode", AttributeVideoID.String(videoID))
+	res, err := bh.Retranscode(videoID, profile)
+	if err == nil {
+		span.SetAttributes(AttributeJobID.String(res.ID))
+	}
+	endSpan(span, err)
+	return res, err
+}
+
+// GetIngestJob :nodoc:
+func (c *Client) GetIngestJob(videoID, jobID string) (*brighthub.IngestJob, error) {
+	bh, span := c.start("GetIngestJob", AttributeVideoID.String(videoID), AttributeJobID.String(jobID))
+	res, err := bh.GetIngestJob(videoID, jobID)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetVideoMasterInfo :nodoc:
+func (c *Client) GetVideoMasterInfo(videoID string) (*brighthub.VideoMasterInfo, error) {
+	bh, span := c.start("GetVideoMasterInfo", AttributeVideoID.String(videoID))
+	res, err := bh.GetVideoMasterInfo(videoID)
+	endSpan(span, err)
+	return res, err
+}
+
+// DeleteDigitalMaster :nodoc:
+func (c *Client) DeleteDigitalMaster(videoID string) error {
+	bh, span := c.start("DeleteDigitalMaster", AttributeVideoID.String(videoID))
+	err := bh.DeleteDigitalMaster(videoID)
+	endSpan(span, err)
+	return err
+}
+
+// GetVideoSources :nodoc:
+func (c *Client) GetVideoSources(videoID string) ([]*brighthub.VideoSource, error) {
+	bh, span := c.start("GetVideoSources", AttributeVideoID.String(videoID))
+	res, err := bh.GetVideoSources(videoID)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetVideoRenditions :nodoc:
+func (c *Client) GetVideoRenditions(videoID string) ([]*brighthub.VideoRendition, error) {
+	bh, span := c.start("GetVideoRenditions", AttributeVideoID.String(videoID))
+	res, err := bh.GetVideoRenditions(videoID)
+	endSpan(span, err)
+	return res, err
+}
+
+// GetVideoDynamicRenditions :nodoc:
+func (c *Client) GetVideoDynamicRenditions(videoID string) ([]*brighthub.DynamicRendition, error) {
+	bh, span := c.start("GetVideoDynamicRenditions", AttributeVideoID.String(videoID))
+	res, err := bh.GetVideoDynamicRenditions(videoID)
+	endSpan(span, err)
+	return res, err
+}
+
+// ListIngestProfiles :nodoc:
+func (c *Client) ListIngestProfiles() ([]*brighthub.IngestProfile, error) {
+	bh, span := c.start("ListIngestProfiles")
+	res, err := bh.ListIngestProfiles()
+	endSpan(span, err)
+	return res, err
+}
+
+// CreateIngestProfile :nodoc:
+func (c *Client) CreateIngestProfile(profile *brighthub.IngestProfile) (*brighthub.IngestProfile, error) {
+	bh, span := c.start("CreateIngestProfile")
+	res, err := bh.CreateIngestProfile(profile)
+	endSpan(span, err)
+	return res, err
+}
+
+// UpdateIngestProfile :nodoc:
+func (c *Client) UpdateIngestProfile(profile *brighthub.IngestProfile) (*brighthub.IngestProfile, error) {
+	bh, span := c.start("UpdateIngestProfile")
+	res, err := bh.UpdateIngestProfile(profile)
+	endSpan(span, err)
+	return res, err
+}
+
+// DeleteIngestProfile :nodoc:
+func (c *Client) DeleteIngestProfile(id string) error {
+	bh, span := c.start("DeleteIngestProfile")
+	err := bh.DeleteIngestProfile(id)
+	endSpan(span, err)
+	return err
+}
+
+// GetDefaultIngestProfile :nodoc:
+func (c *Client) GetDefaultIngestProfile() (*brighthub.IngestProfileConfiguration, error) {
+	bh, span := c.start("GetDefaultIngestProfile")
+	res, err := bh.GetDefaultIngestProfile()
+	endSpan(span, err)
+	return res, err
+}
+
+// SetDefaultIngestProfile :nodoc:
+func (c *Client) SetDefaultIngestProfile(id string) (*brighthub.IngestProfileConfiguration, error) {
+	bh, span := c.start("SetDefaultIngestProfile")
+	res, err := bh.SetDefaultIngestProfile(id)
+	endSpan(span, err)
+	return res, err
+}
@@ -0,0 +1,148 @@
+// Package brighthubotel OpenTelemetry tracing of brighthub.Client, every operation creates a span and
+// every access token refresh creates a child span of the operation which needs it.
+// It is a separate module, so brighthub doesn't depend on OpenTelemetry.
+//
+//	bh, err := brighthubotel.New(clientID, clientSecret, accountID, nil, nil)
+//	video, err := bh.WithContext(ctx).GetVideo(videoID)
+package brighthubotel
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/kumparan/brighthub"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	instrumentationName = "github.com/kumparan/brighthub/brighthubotel"
+
+	// AttributeAccountID :nodoc:
+	AttributeAccountID = attribute.Key("brightcove.account_id")
+	// AttributeVideoID :nodoc:
+	AttributeVideoID = attribute.Key("brightcove.video_id")
+	// AttributeReferenceID :nodoc:
+	AttributeReferenceID = attribute.Key("brightcove.reference_id")
+	// AttributeJobID ingest job id
+	AttributeJobID = attribute.Key("brightcove.job_id")
+	// AttributeErrorCode brighthub.ErrorClass of the failed operation
+	AttributeErrorCode = attribute.Key("brightcove.error_code")
+	// AttributeAPI :nodoc:
+	AttributeAPI = attribute.Key("brightcove.api")
+	// AttributeStatusCode :nodoc:
+	AttributeStatusCode = attribute.Key("http.response.status_code")
+)
+
+type (
+	// Client brighthub.Client which trace every operation, use WithContext to set the parent span
+	Client struct {
+		next      brighthub.Client
+		accountID string
+		tracer    trace.Tracer
+		ctx       context.Context
+	}
+
+	transport struct {
+		next   http.RoundTripper
+		tracer trace.Tracer
+	}
+)
+
+var _ brighthub.Client = (*Client)(nil)
+
+// New create brighthub.Client traced with tp, tp default to the global tracer provider
+func New(clientID, clientSecret, accountID string, httpClient *http.Client, tp trace.TracerProvider, opts ...brighthub.Option) (*Client, error) {
+	if httpClient == nil {
+		// same timeout as the brighthub default http client
+		httpClient = &http.Client{Timeout: 5 * time.Second}
+	}
+	traced := *httpClient
+	traced.Transport = NewTransport(httpClient.Transport, tp)
+
+	bh, err := brighthub.New(clientID, clientSecret, accountID, &traced, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return NewClient(bh, accountID, tp), nil
+}
+
+// NewClient trace operations of c, use New or NewTransport as the c transport to trace token refreshes too
+func NewClient(c brighthub.Client, accountID string, tp trace.TracerProvider) *Client {
+	return &Client{
+		next:      c,
+		accountID: accountID,
+		tracer:    tracer(tp),
+		ctx:       context.Background(),
+	}
+}
+
+// NewTransport trace access token requests, next default to http.DefaultTransport
+func NewTransport(next http.RoundTripper, tp trace.TracerProvider) http.RoundTripper {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &transport{next: next, tracer: tracer(tp)}
+}
+
+func tracer(tp trace.TracerProvider) trace.Tracer {
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(instrumentationName)
+}
+
+// RoundTrip :nodoc:
+func (t *transport) RoundTrip(r *http.Request) (*http.Response, error) {
+	api, _ := brighthub.RequestOperation(r)
+	if api != brighthub.APIOAuth {
+		return t.next.RoundTrip(r)
+	}
+
+	ctx, span := t.tracer.Start(r.Context(), "brighthub.RefreshAccessToken",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(AttributeAPI.String(string(api))))
+	defer span.End()
+
+	resp, err := t.next.RoundTrip(r.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return resp, err
+	}
+	span.SetAttributes(AttributeStatusCode.Int(resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+	return resp, nil
+}
+
+// WithContext operations of the returned client are children of the span in ctx
+func (c *Client) WithContext(ctx context.Context) brighthub.Client {
+	cc := *c
+	cc.ctx = ctx
+	return &cc
+}
+
+// start span of the operation, the returned client send its requests with the span context
+func (c *Client) start(operation string, attrs ...attribute.KeyValue) (brighthub.Client, trace.Span) {
+	attrs = append(attrs, AttributeAccountID.String(c.accountID))
+	ctx, span := c.tracer.Start(c.ctx, "brighthub."+operation,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...))
+	return c.next.WithContext(ctx), span
+}
+
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		if class := brighthub.ErrorClassOf(err); class != "" {
+			span.SetAttributes(AttributeErrorCode.String(string(class)))
+		}
+	}
+	span.End()
+}
@@ -0,0 +1,107 @@
+package brighthubotel
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/kumparan/brighthub/brighthubtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func attributeValue(span sdktrace.ReadOnlySpan, key attribute.Key) string {
+	for _, kv := range span.Attributes() {
+		if kv.Key == key {
+			return kv.Value.Emit()
+		}
+	}
+	return ""
+}
+
+func TestClient(t *testing.T) {
+	s := brighthubtest.NewServer("account-id", "client-id", "client-secret")
+	defer s.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	bh, err := New(s.ClientID, s.ClientSecret, s.AccountID, s.Client(), tp)
+	require.NoError(t, err)
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "upload")
+	created, err := bh.WithContext(ctx).CreateVideo(&brighthub.CreateVideoRequest{Name: "Banjir Jakarta"})
+	require.NoError(t, err)
+
+	_, err = bh.WithContext(ctx).IngestVideo(created.ID, &brighthub.IngestVideoRequest{
+		Master:   &brighthub.IngestVideoMaster{URL: "https://kumparan.com/banjir.mp4"},
+		Priority: brighthub.PriorityNormal,
+	})
+	require.NoError(t, err)
+
+	_, err = bh.WithContext(ctx).GetVideo("not-found")
+	assert.Equal(t, brighthub.ErrResourceNotFound, err)
+	parent.End()
+
+	spans := map[string]sdktrace.ReadOnlySpan{}
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+
+	create := spans["brighthub.CreateVideo"]
+	require.NotNil(t, create)
+	assert.Equal(t, parent.SpanContext().SpanID(), create.Parent().SpanID())
+	assert.Equal(t, "account-id", attributeValue(create, AttributeAccountID))
+	assert.Equal(t, created.ID, attributeValue(create, AttributeVideoID))
+
+	ingest := spans["brighthub.IngestVideo"]
+	require.NotNil(t, ingest)
+	assert.Equal(t, created.ID, attributeValue(ingest, AttributeVideoID))
+	assert.NotEmpty(t, attributeValue(ingest, AttributeJobID))
+
+	// the first token is acquired by New
+	assert.NotNil(t, spans["brighthub.RefreshAccessToken"])
+
+	get := spans["brighthub.GetVideo"]
+	require.NotNil(t, get)
+	assert.Equal(t, codes.Error, get.Status().Code)
+	assert.Equal(t, string(brighthub.ErrorClassNotFound), attributeValue(get, AttributeErrorCode))
+}
+
+func TestNewTransport(t *testing.T) {
+	s := brighthubtest.NewServer("account-id", "client-id", "client-secret")
+	defer s.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+	httpClient := &http.Client{Transport: NewTransport(s.Client().Transport, tp)}
+
+	ctx, parent := tp.Tracer("test").Start(context.Background(), "brighthub.GetVideo")
+	r, err := http.NewRequest("POST", "https://oauth.brightcove.com/v4/access_token?grant_type=client_credentials", nil)
+	require.NoError(t, err)
+	r.SetBasicAuth("client-id", "wrong-secret")
+	resp, err := httpClient.Do(r.WithContext(ctx))
+	require.NoError(t, err)
+	resp.Body.Close()
+
+	// only token requests are traced
+	r, err = http.NewRequest("GET", "https://cms.api.brightcove.com/v1/accounts/account-id/videos", nil)
+	require.NoError(t, err)
+	resp, err = httpClient.Do(r.WithContext(ctx))
+	require.NoError(t, err)
+	resp.Body.Close()
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Equal(t, 2, len(spans))
+	refresh := spans[0]
+	assert.Equal(t, "brighthub.RefreshAccessToken", refresh.Name())
+	assert.Equal(t, parent.SpanContext().SpanID(), refresh.Parent().SpanID())
+	assert.Equal(t, codes.Error, refresh.Status().Code)
+	assert.Equal(t, strconv.Itoa(http.StatusUnauthorized), attributeValue(refresh, AttributeStatusCode))
+}
@@ -0,0 +1,9 @@
+module github.com/kumparan/brighthub/brighthubprom
+
+go 1.12
+
+require (
+	github.com/kumparan/brighthub v0.1.0
+	github.com/prometheus/client_golang v0.9.4
+	github.com/stretchr/testify v1.3.0
+)
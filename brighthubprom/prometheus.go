@@ -0,0 +1,70 @@
+// Package brighthubprom Prometheus collectors of brighthub requests.
+//
+//	metrics := brighthubprom.New("")
+//	prometheus.MustRegister(metrics)
+//	httpClient := &http.Client{Transport: brighthub.NewInstrumentedTransport(http.DefaultTransport, metrics)}
+package brighthubprom
+
+import (
+	"strconv"
+
+	"github.com/kumparan/brighthub"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics brighthub.Instrumentation and prometheus.Collector, the caller registers it
+type Metrics struct {
+	requests *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+	retries  *prometheus.CounterVec
+}
+
+// New namespace default to brighthub
+func New(namespace string) *Metrics {
+	if namespace == "" {
+		namespace = "brighthub"
+	}
+
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Brightcove API requests, error_class is empty for succeeded requests.",
+		}, []string{"api", "operation", "status_code", "error_class"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Brightcove API request latency.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"api", "operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "request_retries_total",
+			Help:      "Brightcove API request retries.",
+		}, []string{"api", "operation"}),
+	}
+}
+
+// ObserveRequest :nodoc:
+func (m *Metrics) ObserveRequest(event *brighthub.RequestEvent) {
+	api := string(event.API)
+	m.requests.WithLabelValues(api, event.Operation, strconv.Itoa(event.StatusCode), string(event.ErrorClass)).Inc()
+	m.latency.WithLabelValues(api, event.Operation).Observe(event.Latency.Seconds())
+	if event.Retries > 0 {
+		m.retries.WithLabelValues(api, event.Operation).Add(float64(event.Retries))
+	}
+}
+
+// Describe :nodoc:
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.latency.Describe(ch)
+	m.retries.Describe(ch)
+}
+
+// Collect :nodoc:
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.latency.Collect(ch)
+	m.retries.Collect(ch)
+}
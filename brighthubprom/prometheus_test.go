@@ -0,0 +1,32 @@
+package brighthubprom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kumparan/brighthub"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New("")
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(m))
+
+	m.ObserveRequest(&brighthub.RequestEvent{API: brighthub.APICMS, Operation: "CreateVideo", StatusCode: 201, Latency: time.Second})
+	m.ObserveRequest(&brighthub.RequestEvent{API: brighthub.APICMS, Operation: "CreateVideo", StatusCode: 500,
+		ErrorClass: brighthub.ErrorClassInternalError, Latency: time.Second, Retries: 2})
+
+	assert.Equal(t, float64(1), testutil.ToFloat64(m.requests.WithLabelValues("cms", "CreateVideo", "500", "internal_error")))
+	assert.Equal(t, float64(2), testutil.ToFloat64(m.retries.WithLabelValues("cms", "CreateVideo")))
+
+	families, err := registry.Gather()
+	assert.NoError(t, err)
+	var names []string
+	for _, f := range families {
+		names = append(names, f.GetName())
+	}
+	assert.Equal(t, []string{"brighthub_request_duration_seconds", "brighthub_request_retries_total", "brighthub_requests_total"}, names)
+}
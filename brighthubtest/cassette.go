@@ -0,0 +1,322 @@
+package brighthubtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+type (
+	// Cassette recorded Brightcove interactions
+	Cassette struct {
+		Interactions []*Interaction `json:"interactions"`
+	}
+
+	// Interaction :nodoc:
+	Interaction struct {
+		Request  *RecordedRequest  `json:"request"`
+		Response *RecordedResponse `json:"response"`
+	}
+
+	// RecordedRequest :nodoc:
+	RecordedRequest struct {
+		Method string      `json:"method"`
+		URL    string      `json:"url"`
+		Header http.Header `json:"header"`
+		Body   string      `json:"body"`
+	}
+
+	// RecordedResponse :nodoc:
+	RecordedResponse struct {
+		StatusCode int         `json:"status_code"`
+		Header     http.Header `json:"header"`
+		Body       string      `json:"body"`
+	}
+
+	// Recorder http.RoundTripper which records every interaction, Save it into cassette file when done
+	Recorder struct {
+		// Transport sends the actual requests, default to http.DefaultTransport
+		Transport http.RoundTripper
+
+		path     string
+		mu       sync.Mutex
+		cassette *Cassette
+	}
+
+	// Replayer http.RoundTripper which serves interactions of cassette file,
+	// each interaction is served once in the recorded order
+	Replayer struct {
+		mu       sync.Mutex
+		cassette *Cassette
+		used     []bool
+	}
+)
+
+const redacted = "REDACTED"
+
+var (
+	// ErrUnmatchedRequest request has no unused matching interaction in the cassette
+	ErrUnmatchedRequest = errors.New("brighthubtest: request doesn't match any recorded interaction")
+
+	// scrubbedHeaders hold credentials, they are not compared when replaying
+	scrubbedHeaders = []string{"Authorization", "X-Api-Key", "Bcov-Policy"}
+	// scrubbedFields credentials in query, form and JSON bodies
+	scrubbedFields = []string{"client_id", "client_secret", "access_token", "policy_key"}
+	// policyKeyParam Playback API policy key in Accept header, e.g. application/json;pk=...
+	policyKeyParam = regexp.MustCompile(`(?i)(;\s*pk=)[^;,\s]+`)
+)
+
+// NewRecorder :nodoc:
+func NewRecorder(path string, transport http.RoundTripper) *Recorder {
+	return &Recorder{
+		Transport: transport,
+		path:      path,
+		cassette:  new(Cassette),
+	}
+}
+
+// Client http client to be passed to brighthub.New
+func (r *Recorder) Client() *http.Client {
+	return &http.Client{Transport: r, Timeout: 30 * time.Second}
+}
+
+// RoundTrip :nodoc:
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = b
+		req.Body = ioutil.NopCloser(bytes.NewReader(b))
+	}
+
+	transport := r.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cassette.Interactions = append(r.cassette.Interactions, &Interaction{
+		Request: &RecordedRequest{
+			Method: req.Method,
+			URL:    scrubURL(req.URL),
+			Header: scrubHeader(req.Header),
+			Body:   scrubBody(reqBody),
+		},
+		Response: &RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     scrubHeader(resp.Header),
+			Body:       scrubBody(respBody),
+		},
+	})
+	return resp, nil
+}
+
+// Save write the recorded interactions into the cassette file
+func (r *Recorder) Save() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	b, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, b, 0644)
+}
+
+// NewReplayer load cassette file
+func NewReplayer(path string) (*Replayer, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	cassette := new(Cassette)
+	err = json.Unmarshal(b, cassette)
+	if err != nil {
+		return nil, err
+	}
+	return &Replayer{
+		cassette: cassette,
+		used:     make([]bool, len(cassette.Interactions)),
+	}, nil
+}
+
+// Client http client to be passed to brighthub.New
+func (r *Replayer) Client() *http.Client {
+	return &http.Client{Transport: r, Timeout: 30 * time.Second}
+}
+
+// Unused interactions which haven't been served, tests may assert it is empty
+func (r *Replayer) Unused() []*Interaction {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var unused []*Interaction
+	for i, in := range r.cassette.Interactions {
+		if !r.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
+
+// RoundTrip serve the first unused interaction with the same method, url and body,
+// credentials are scrubbed before comparing
+func (r *Replayer) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		body = b
+	}
+	u := scrubURL(req.URL)
+	scrubbedBody := scrubBody(body)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		if r.used[i] || in.Request.Method != req.Method || in.Request.URL != u || in.Request.Body != scrubbedBody {
+			continue
+		}
+		r.used[i] = true
+
+		header := http.Header{}
+		for k, v := range in.Response.Header {
+			header[k] = v
+		}
+		return &http.Response{
+			Status:        http.StatusText(in.Response.StatusCode),
+			StatusCode:    in.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			ContentLength: int64(len(in.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, ErrUnmatchedRequest
+}
+
+func scrubHeader(h http.Header) http.Header {
+	scrubbed := http.Header{}
+	for k, v := range h {
+		scrubbed[k] = v
+	}
+	for _, k := range scrubbedHeaders {
+		if scrubbed.Get(k) != "" {
+			scrubbed.Set(k, redacted)
+		}
+	}
+	if accept, ok := scrubbed["Accept"]; ok {
+		values := make([]string, len(accept))
+		for i, v := range accept {
+			values[i] = policyKeyParam.ReplaceAllString(v, "${1}"+redacted)
+		}
+		scrubbed["Accept"] = values
+	}
+	// bookkeeping of the fake server, it is not part of the Brightcove interaction
+	scrubbed.Del(hostHeader)
+	return scrubbed
+}
+
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	query := scrubbed.Query()
+	for _, f := range scrubbedFields {
+		if query.Get(f) != "" {
+			query.Set(f, redacted)
+		}
+	}
+	scrubbed.RawQuery = query.Encode()
+	return scrubbed.String()
+}
+
+// scrubBody scrub credentials of JSON or form body, other bodies are kept as is
+func scrubBody(b []byte) string {
+	trimmed := strings.TrimSpace(string(b))
+	if trimmed == "" {
+		return ""
+	}
+
+	var value interface{}
+	if err := json.Unmarshal(b, &value); err == nil {
+		if !scrubJSON(value) {
+			return trimmed
+		}
+		s, _ := json.Marshal(value)
+		return string(s)
+	}
+
+	if form, err := url.ParseQuery(trimmed); err == nil && trimmed[0] != '{' && strings.Contains(trimmed, "=") {
+		for _, f := range scrubbedFields {
+			if form.Get(f) != "" {
+				form.Set(f, redacted)
+			}
+		}
+		return form.Encode()
+	}
+	return trimmed
+}
+
+// scrubJSON redact credentials at any depth of decoded JSON, e.g. the policy key in Player API configuration,
+// true when something is redacted
+func scrubJSON(value interface{}) bool {
+	scrubbed := false
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if isScrubbedField(k) {
+				v[k] = redacted
+				scrubbed = true
+				continue
+			}
+			if scrubJSON(child) {
+				scrubbed = true
+			}
+		}
+	case []interface{}:
+		for _, child := range v {
+			if scrubJSON(child) {
+				scrubbed = true
+			}
+		}
+	}
+	return scrubbed
+}
+
+func isScrubbedField(key string) bool {
+	for _, f := range scrubbedFields {
+		if key == f {
+			return true
+		}
+	}
+	return false
+}
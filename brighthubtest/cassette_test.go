@@ -0,0 +1,149 @@
+package brighthubtest
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecorder_Replayer(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthubtest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	s := NewServer("account-id", "client-id", "client-secret")
+	videoID := s.AddVideo(&brighthub.Video{Name: "Banjir Jakarta", ReferenceID: "story-1"})
+
+	recorder := NewRecorder(path, s.Client().Transport)
+	bh, err := brighthub.New("client-id", "client-secret", "account-id", recorder.Client())
+	assert.NoError(t, err)
+	recorded, err := bh.GetVideoByReferenceID("story-1")
+	assert.NoError(t, err)
+	_, err = bh.UpdateVideo(videoID, &brighthub.UpdateVideoRequest{Tags: []string{"banjir"}})
+	assert.NoError(t, err)
+	_, err = bh.GetVideo("404")
+	assert.Equal(t, brighthub.ErrResourceNotFound, err)
+	assert.NoError(t, recorder.Save())
+	s.Close()
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(string(b), "fake-token"))
+	assert.False(t, strings.Contains(string(b), "Basic "))
+	assert.True(t, strings.Contains(string(b), redacted))
+
+	replayer, err := NewReplayer(path)
+	assert.NoError(t, err)
+	bh, err = brighthub.New("other-client-id", "other-client-secret", "account-id", replayer.Client())
+	assert.NoError(t, err)
+
+	replayed, err := bh.GetVideoByReferenceID("story-1")
+	assert.NoError(t, err)
+	assert.Equal(t, recorded, replayed)
+	video, err := bh.UpdateVideo(videoID, &brighthub.UpdateVideoRequest{Tags: []string{"banjir"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"banjir"}, video.Tags)
+	_, err = bh.GetVideo("404")
+	assert.Equal(t, brighthub.ErrResourceNotFound, err)
+	assert.Empty(t, replayer.Unused())
+
+	// every interaction is served once
+	_, err = bh.GetVideoByReferenceID("story-1")
+	assert.Error(t, err)
+	assert.True(t, strings.Contains(err.Error(), ErrUnmatchedRequest.Error()))
+
+	_, err = bh.UpdateVideo(videoID, &brighthub.UpdateVideoRequest{Tags: []string{"politik"}})
+	assert.Error(t, err)
+}
+
+func TestScrubBody(t *testing.T) {
+	assert.Equal(t, `{"access_token":"REDACTED","expires_in":300}`, scrubBody([]byte(`{"access_token": "secret", "expires_in": 300}`)))
+	assert.Equal(t, "client_id=REDACTED&client_secret=REDACTED&grant_type=client_credentials", scrubBody([]byte("grant_type=client_credentials&client_id=id&client_secret=secret")))
+	assert.Equal(t, `[{"id":"1"}]`, scrubBody([]byte("[{\"id\":\"1\"}]\n")))
+	assert.Equal(t, `{"name":"a=b"}`, scrubBody([]byte(`{"name":"a=b"}`)))
+	assert.Equal(t, `[{"access_token":"REDACTED"}]`, scrubBody([]byte(`[{"access_token": "secret"}]`)))
+	assert.Equal(t, `{"branches":{"master":{"configuration":{"video_cloud":{"policy_key":"REDACTED"}}}}}`,
+		scrubBody([]byte(`{"branches":{"master":{"configuration":{"video_cloud":{"policy_key":"BCpk-secret"}}}}}`)))
+	assert.Equal(t, "", scrubBody(nil))
+}
+
+type roundTripperFunc func(r *http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRecorder_ScrubPolicyKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthubtest")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "cassette.json")
+
+	recorder := NewRecorder(path, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		// the actual request still carries the policy key
+		assert.Equal(t, "application/json;pk=BCpkADawqM-secret", r.Header.Get("Accept"))
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       ioutil.NopCloser(strings.NewReader(`{"id": "12345"}`)),
+		}, nil
+	}))
+
+	r, err := http.NewRequest("GET", "https://edge.api.brightcove.com/playback/v1/accounts/account-id/videos/12345?policy_key=BCpkADawqM-secret", nil)
+	assert.NoError(t, err)
+	r.Header.Set("Accept", "application/json;pk=BCpkADawqM-secret")
+	_, err = recorder.Client().Do(r)
+	assert.NoError(t, err)
+	assert.NoError(t, recorder.Save())
+
+	b, err := ioutil.ReadFile(path)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(b), "BCpkADawqM-secret")
+	assert.Contains(t, string(b), "application/json;pk=REDACTED")
+	assert.Contains(t, string(b), "policy_key=REDACTED")
+}
+
+func TestRecorder_ScrubNestedCredentials(t *testing.T) {
+	bodies := map[string]string{
+		"Nested policy key": `{"id": "player-1", "branches": {"master": {"configuration": {"video_cloud": {"policy_key": "BCpkADawqM-secret"}}}}}`,
+		"Array body":        `[{"id": "1", "client_secret": "BCpkADawqM-secret"}, {"id": "2", "credentials": [{"access_token": "BCpkADawqM-secret"}]}]`,
+	}
+	for name, body := range bodies {
+		t.Run(name, func(t *testing.T) {
+			dir, err := ioutil.TempDir("", "brighthubtest")
+			assert.NoError(t, err)
+			defer os.RemoveAll(dir)
+			path := filepath.Join(dir, "cassette.json")
+
+			recorder := NewRecorder(path, roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+				return &http.Response{
+					StatusCode: http.StatusOK,
+					Header:     http.Header{"Content-Type": {"application/json"}},
+					Body:       ioutil.NopCloser(strings.NewReader(body)),
+				}, nil
+			}))
+
+			r, err := http.NewRequest("POST", "https://players.api.brightcove.com/v2/accounts/account-id/players", strings.NewReader(body))
+			assert.NoError(t, err)
+			resp, err := recorder.Client().Do(r)
+			assert.NoError(t, err)
+			// the caller still gets the actual response
+			b, err := ioutil.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, body, string(b))
+			assert.NoError(t, recorder.Save())
+
+			b, err = ioutil.ReadFile(path)
+			assert.NoError(t, err)
+			assert.NotContains(t, string(b), "BCpkADawqM-secret")
+			assert.Contains(t, string(b), redacted)
+		})
+	}
+}
@@ -0,0 +1,564 @@
+package brighthubtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+// AddVideo store video as if it was created through CMS API, ID is generated when empty
+func (s *Server) AddVideo(video *brighthub.Video) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v := new(brighthub.Video)
+	clone(video, v)
+	if v.ID == "" {
+		v.ID = s.newID()
+	}
+	v.AccountID = s.AccountID
+	s.putVideo(v)
+	return v.ID
+}
+
+// Video copy of the stored video, nil when it doesn't exist
+func (s *Server) Video(videoID string) *brighthub.Video {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	v, ok := s.videos[videoID]
+	if !ok {
+		return nil
+	}
+	video := new(brighthub.Video)
+	clone(v, video)
+	return video
+}
+
+// AddFolder CMS folder must exist before videos are added into it, the folder id is also its name
+func (s *Server) AddFolder(folderID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.folders[folderID]; !ok {
+		s.folders[folderID] = map[string]bool{}
+		s.folderNames[folderID] = folderID
+	}
+}
+
+// AddPlaylist add CMS playlist, return its id
+func (s *Server) AddPlaylist(playlist *brighthub.Playlist) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	p := new(brighthub.Playlist)
+	clone(playlist, p)
+	if p.ID == "" {
+		p.ID = s.newID()
+	}
+	p.AccountID = s.AccountID
+	s.playlists = append(s.playlists, p)
+	return p.ID
+}
+
+// AddCustomField define custom field of the account
+func (s *Server) AddCustomField(field *brighthub.VideoField) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	f := new(brighthub.VideoField)
+	clone(field, f)
+	s.customFields = append(s.customFields, f)
+}
+
+// FolderVideos id of the videos in the folder, sorted
+func (s *Server) FolderVideos(folderID string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var ids []string
+	for id := range s.folders[folderID] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// putVideo caller must hold s.mu
+func (s *Server) putVideo(v *brighthub.Video) {
+	if _, ok := s.videos[v.ID]; !ok {
+		s.videoOrder = append(s.videoOrder, v.ID)
+	}
+	now := brighthub.Timestamp{Time: time.Now().UTC()}
+	if v.CreatedAt.IsZero() {
+		v.CreatedAt = now
+	}
+	v.UpdatedAt = now
+	if v.State == "" {
+		v.State = brighthub.StateActive
+	}
+	s.videos[v.ID] = v
+}
+
+func (s *Server) findVideo(id string) *brighthub.Video {
+	if !strings.HasPrefix(id, "ref:") {
+		return s.videos[id]
+	}
+	for _, v := range s.videos {
+		if v.ReferenceID != "" && v.ReferenceID == strings.TrimPrefix(id, "ref:") {
+			return v
+		}
+	}
+	return nil
+}
+
+func (s *Server) referenceIDTaken(referenceID, exceptID string) bool {
+	if referenceID == "" {
+		return false
+	}
+	for _, v := range s.videos {
+		if v.ReferenceID == referenceID && v.ID != exceptID {
+			return true
+		}
+	}
+	return false
+}
+
+// serveCMS path is after /accounts/{account_id}
+func (s *Server) serveCMS(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 1 && path[0] == "videos" && r.Method == "POST":
+		s.createVideo(w, r)
+	case len(path) == 1 && path[0] == "videos" && r.Method == "GET":
+		s.listVideos(w, r)
+	case len(path) == 1 && path[0] == "folders" && r.Method == "GET":
+		s.listFolders(w)
+	case len(path) == 1 && path[0] == "folders" && r.Method == "POST":
+		s.createFolder(w, r)
+	case len(path) == 4 && path[0] == "folders" && path[2] == "videos" && r.Method == "PUT":
+		s.addVideoToFolder(w, path[1], path[3])
+	case len(path) == 1 && path[0] == "playlists" && r.Method == "GET":
+		s.listPlaylists(w, r)
+	case len(path) == 1 && path[0] == "video_fields" && r.Method == "GET":
+		s.videoFields(w)
+	case len(path) >= 2 && path[0] == "videos":
+		video := s.findVideo(path[1])
+		if video == nil {
+			writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "video not found")
+			return
+		}
+		s.serveVideo(w, r, video, strings.Join(path[2:], "/"))
+	default:
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "resource not found")
+	}
+}
+
+func (s *Server) serveVideo(w http.ResponseWriter, r *http.Request, video *brighthub.Video, sub string) {
+	master, ingested := s.masters[video.ID]
+	switch {
+	case sub == "" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, video)
+	case sub == "" && r.Method == "PATCH":
+		s.updateVideo(w, r, video)
+	case sub == "digital_master" && r.Method == "GET" && ingested:
+		writeJSON(w, http.StatusOK, master)
+	case sub == "digital_master" && r.Method == "DELETE" && ingested:
+		delete(s.masters, video.ID)
+		w.WriteHeader(http.StatusNoContent)
+	case sub == "sources" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.sources(video.ID, ingested))
+	case sub == "assets/renditions" && r.Method == "GET":
+		// fake videos are always Dynamic Delivery, which has no legacy renditions
+		writeJSON(w, http.StatusOK, []*brighthub.VideoRendition{})
+	case sub == "assets/dynamic_renditions" && r.Method == "GET":
+		writeJSON(w, http.StatusOK, s.dynamicRenditions(video, ingested))
+	case strings.HasPrefix(sub, "ingest_jobs/") && r.Method == "GET":
+		s.getIngestJob(w, video.ID, strings.TrimPrefix(sub, "ingest_jobs/"))
+	default:
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "resource not found")
+	}
+}
+
+func (s *Server) createVideo(w http.ResponseWriter, r *http.Request) {
+	req := new(brighthub.CreateVideoRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", err.Error())
+		return
+	}
+	if req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", "name is required")
+		return
+	}
+	if s.referenceIDTaken(req.ReferenceID, "") {
+		writeError(w, http.StatusConflict, "REFERENCE_ID_IN_USE", "reference id is already in use")
+		return
+	}
+
+	video := &brighthub.Video{
+		ID:              s.newID(),
+		AccountID:       s.AccountID,
+		Name:            req.Name,
+		Description:     req.Description,
+		LongDescription: req.LongDescription,
+		ReferenceID:     req.ReferenceID,
+		State:           req.State,
+		Tags:            req.Tags,
+		CustomFields:    req.CustomFields,
+		CuePoints:       req.CuePoints,
+		Schedule:        req.Schedule,
+		Geo:             req.Geo,
+		DeliveryType:    "dynamic_origin",
+	}
+	s.putVideo(video)
+	writeJSON(w, http.StatusCreated, video)
+}
+
+func (s *Server) updateVideo(w http.ResponseWriter, r *http.Request, video *brighthub.Video) {
+	req := new(brighthub.UpdateVideoRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", err.Error())
+		return
+	}
+	if s.referenceIDTaken(req.ReferenceID, video.ID) {
+		writeError(w, http.StatusConflict, "REFERENCE_ID_IN_USE", "reference id is already in use")
+		return
+	}
+
+	if req.Name != "" {
+		video.Name = req.Name
+	}
+	if req.Description != nil {
+		video.Description = *req.Description
+	}
+	if req.LongDescription != nil {
+		video.LongDescription = *req.LongDescription
+	}
+	if req.ReferenceID != "" {
+		video.ReferenceID = req.ReferenceID
+	}
+	if req.State != "" {
+		video.State = req.State
+	}
+	if req.Tags != nil {
+		video.Tags = req.Tags
+	}
+	// custom fields are merged, empty value removes the field
+	for k, v := range req.CustomFields {
+		if video.CustomFields == nil {
+			video.CustomFields = map[string]string{}
+		}
+		if v == "" {
+			delete(video.CustomFields, k)
+			continue
+		}
+		video.CustomFields[k] = v
+	}
+	if req.CuePoints != nil {
+		video.CuePoints = req.CuePoints
+	}
+	if req.Schedule != nil {
+		video.Schedule = req.Schedule
+		if req.Schedule.StartsAt == nil && req.Schedule.EndsAt == nil {
+			video.Schedule = nil
+		}
+	}
+	if req.Geo != nil {
+		video.Geo = req.Geo
+		if !req.Geo.Restricted && len(req.Geo.Countries) == 0 {
+			video.Geo = nil
+		}
+	}
+	s.putVideo(video)
+	writeJSON(w, http.StatusOK, video)
+}
+
+// listVideos supports plain words matching the name and the terms of matchTerm,
+// queries with any other search field are rejected with bad request
+func (s *Server) listVideos(w http.ResponseWriter, r *http.Request) {
+	params := r.URL.Query()
+	q := params.Get("q")
+	if err := validateQuery(q); err != nil {
+		writeError(w, http.StatusBadRequest, "INVALID_SEARCH", err.Error())
+		return
+	}
+	var videos []*brighthub.Video
+	for _, id := range s.videoOrder {
+		if v := s.videos[id]; matchQuery(v, q) {
+			videos = append(videos, v)
+		}
+	}
+
+	switch params.Get("sort") {
+	case "name":
+		sort.SliceStable(videos, func(i, j int) bool { return videos[i].Name < videos[j].Name })
+	case "-name":
+		sort.SliceStable(videos, func(i, j int) bool { return videos[i].Name > videos[j].Name })
+	case "-created_at":
+		sort.SliceStable(videos, func(i, j int) bool { return videos[i].CreatedAt.After(videos[j].CreatedAt.Time) })
+	case "-updated_at":
+		sort.SliceStable(videos, func(i, j int) bool { return videos[i].UpdatedAt.After(videos[j].UpdatedAt.Time) })
+	case "updated_at":
+		sort.SliceStable(videos, func(i, j int) bool { return videos[i].UpdatedAt.Before(videos[j].UpdatedAt.Time) })
+	}
+
+	limit, offset, ok := pageParams(w, r)
+	if !ok {
+		return
+	}
+
+	page := []*brighthub.Video{}
+	for i := offset; i < len(videos) && i < offset+limit; i++ {
+		page = append(page, videos[i])
+	}
+	writeJSON(w, http.StatusOK, page)
+}
+
+// pageParams limit and offset of the request, write bad request response when they are invalid
+func pageParams(w http.ResponseWriter, r *http.Request) (int, int, bool) {
+	params := r.URL.Query()
+	limit, offset := 20, 0
+	if l, err := strconv.Atoi(params.Get("limit")); err == nil {
+		limit = l
+	}
+	if o, err := strconv.Atoi(params.Get("offset")); err == nil {
+		offset = o
+	}
+	if limit < 1 || limit > 100 || offset < 0 {
+		writeError(w, http.StatusBadRequest, "INVALID_PARAMETER", "limit must be between 1 and 100")
+		return 0, 0, false
+	}
+	return limit, offset, true
+}
+
+// validateQuery every term must be supported by matchTerm
+func validateQuery(q string) error {
+	for _, term := range strings.Fields(q) {
+		_, err := matchTerm(new(brighthub.Video), strings.TrimLeft(term, "+-"))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matchQuery evaluate validated CMS search query, terms prefixed with + must match and terms prefixed with - must not,
+// at least one of the other terms must match when there is no + term
+func matchQuery(v *brighthub.Video, q string) bool {
+	hasRequired, hasOptional, optionalMatched := false, false, false
+	for _, term := range strings.Fields(q) {
+		switch {
+		case strings.HasPrefix(term, "+"):
+			hasRequired = true
+			if match, _ := matchTerm(v, term[1:]); !match {
+				return false
+			}
+		case strings.HasPrefix(term, "-"):
+			if match, _ := matchTerm(v, term[1:]); match {
+				return false
+			}
+		default:
+			hasOptional = true
+			if match, _ := matchTerm(v, term); match {
+				optionalMatched = true
+			}
+		}
+	}
+	return hasRequired || !hasOptional || optionalMatched
+}
+
+// matchTerm supports reference_id, state, tags, geo.restricted, geo.countries and the date ranges of
+// created_at, updated_at, schedule.starts_at and schedule.ends_at
+func matchTerm(v *brighthub.Video, term string) (bool, error) {
+	parts := strings.SplitN(term, ":", 2)
+	if len(parts) == 1 {
+		return strings.Contains(strings.ToLower(v.Name), strings.ToLower(term)), nil
+	}
+
+	value := strings.Trim(parts[1], `"`)
+	switch parts[0] {
+	case "reference_id":
+		return v.ReferenceID == value, nil
+	case "state":
+		return strings.EqualFold(string(v.State), value), nil
+	case "tags":
+		return containsString(v.Tags, value), nil
+	case "geo.restricted":
+		return v.Geo != nil && strconv.FormatBool(v.Geo.Restricted) == value, nil
+	case "geo.countries":
+		return v.Geo != nil && containsString(v.Geo.Countries, strings.ToLower(value)), nil
+	case "created_at":
+		return matchDateRange(&v.CreatedAt.Time, value)
+	case "updated_at":
+		return matchDateRange(&v.UpdatedAt.Time, value)
+	case "schedule.starts_at":
+		if v.Schedule == nil {
+			return matchDateRange(nil, value)
+		}
+		return matchDateRange(v.Schedule.StartsAt, value)
+	case "schedule.ends_at":
+		if v.Schedule == nil {
+			return matchDateRange(nil, value)
+		}
+		return matchDateRange(v.Schedule.EndsAt, value)
+	}
+	return false, fmt.Errorf("search field %s is not supported by brighthubtest", parts[0])
+}
+
+// matchDateRange whether t is within the inclusive range "from..to", either side may be empty
+func matchDateRange(t *time.Time, value string) (bool, error) {
+	bounds := strings.SplitN(value, "..", 2)
+	if len(bounds) != 2 {
+		return false, fmt.Errorf("%s is not a date range", value)
+	}
+	from, err := parseSearchDate(bounds[0])
+	if err != nil {
+		return false, err
+	}
+	to, err := parseSearchDate(bounds[1])
+	if err != nil {
+		return false, err
+	}
+
+	switch {
+	case t == nil || t.IsZero():
+		return false, nil
+	case !from.IsZero() && t.Before(from), !to.IsZero() && t.After(to):
+		return false, nil
+	default:
+		return true, nil
+	}
+}
+
+// parseSearchDate zero time when s is empty
+func parseSearchDate(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339Nano, s)
+}
+
+func containsString(values []string, s string) bool {
+	for _, v := range values {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) listFolders(w http.ResponseWriter) {
+	folders := []*brighthub.Folder{}
+	for id, videos := range s.folders {
+		folders = append(folders, &brighthub.Folder{
+			ID:         id,
+			AccountID:  s.AccountID,
+			Name:       s.folderNames[id],
+			VideoCount: int64(len(videos)),
+		})
+	}
+	sort.Slice(folders, func(i, j int) bool { return folders[i].Name < folders[j].Name })
+	writeJSON(w, http.StatusOK, folders)
+}
+
+func (s *Server) listPlaylists(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := pageParams(w, r)
+	if !ok {
+		return
+	}
+	playlists := []*brighthub.Playlist{}
+	for i := offset; i < len(s.playlists) && len(playlists) < limit; i++ {
+		playlists = append(playlists, s.playlists[i])
+	}
+	writeJSON(w, http.StatusOK, playlists)
+}
+
+func (s *Server) videoFields(w http.ResponseWriter) {
+	fields := &brighthub.VideoFields{
+		MaxCustomFields: 10,
+		CustomFields:    append([]*brighthub.VideoField{}, s.customFields...),
+		StandardFields: []*brighthub.VideoField{
+			{ID: "name", Type: "string", Required: true},
+			{ID: "description", Type: "string"},
+			{ID: "long_description", Type: "string"},
+			{ID: "reference_id", Type: "string"},
+			{ID: "tags", Type: "string"},
+		},
+	}
+	writeJSON(w, http.StatusOK, fields)
+}
+
+func (s *Server) createFolder(w http.ResponseWriter, r *http.Request) {
+	req := new(brighthub.Folder)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil || req.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", "name is required")
+		return
+	}
+	for _, name := range s.folderNames {
+		if name == req.Name {
+			writeError(w, http.StatusConflict, "CONFLICT", "folder name is already in use")
+			return
+		}
+	}
+
+	folder := &brighthub.Folder{ID: s.newID(), AccountID: s.AccountID, Name: req.Name}
+	s.folders[folder.ID] = map[string]bool{}
+	s.folderNames[folder.ID] = folder.Name
+	writeJSON(w, http.StatusCreated, folder)
+}
+
+func (s *Server) addVideoToFolder(w http.ResponseWriter, folderID, videoID string) {
+	folder, ok := s.folders[folderID]
+	video := s.videos[videoID]
+	if !ok || video == nil {
+		writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "folder or video not found")
+		return
+	}
+
+	if video.FolderID != "" {
+		delete(s.folders[video.FolderID], videoID)
+	}
+	folder[videoID] = true
+	video.FolderID = folderID
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (s *Server) sources(videoID string, ingested bool) []*brighthub.VideoSource {
+	if !ingested {
+		return []*brighthub.VideoSource{}
+	}
+	base := fmt.Sprintf("https://manifest.prod.boltdns.net/manifest/v1/hls/v4/clear/%s/%s", s.AccountID, videoID)
+	return []*brighthub.VideoSource{
+		{Src: base + "/master.m3u8", Type: "application/x-mpegURL"},
+		{Src: base + "/video720.mp4", Container: "MP4", Codec: "H264", EncodingRate: 2000000, Height: 720, Width: 1280},
+	}
+}
+
+func (s *Server) dynamicRenditions(video *brighthub.Video, ingested bool) []*brighthub.DynamicRendition {
+	renditions := []*brighthub.DynamicRendition{}
+	profile, ok := s.profiles[s.defaultProfileID]
+	if !ingested || !ok || !profile.IsDynamicDelivery() {
+		return renditions
+	}
+	for _, id := range profile.DynamicOrigin.Renditions {
+		mediaType := "video"
+		if strings.Contains(id, "audio") {
+			mediaType = "audio"
+		}
+		renditions = append(renditions, &brighthub.DynamicRendition{
+			RenditionID: id,
+			MediaType:   mediaType,
+			Duration:    video.Duration,
+			CreatedAt:   video.UpdatedAt,
+			UpdatedAt:   video.UpdatedAt,
+		})
+	}
+	return renditions
+}
@@ -0,0 +1,192 @@
+package brighthubtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+// ingestedDuration duration of every ingested fake video
+const ingestedDuration = time.Minute
+
+// IngestJobs copy of every ingest job, in the order they were requested
+func (s *Server) IngestJobs() []*IngestJob {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	jobs := make([]*IngestJob, len(s.jobs))
+	for i, j := range s.jobs {
+		job := *j
+		jobs[i] = &job
+	}
+	return jobs
+}
+
+// FailNextIngest make the next ingest job fail with the error message
+func (s *Server) FailNextIngest(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ingestError = message
+}
+
+// WaitIngestJobs block until every ingest job finished and sent its notifications
+func (s *Server) WaitIngestJobs() {
+	s.wg.Wait()
+}
+
+// serveDynamicIngest path is after /accounts/{account_id}
+func (s *Server) serveDynamicIngest(w http.ResponseWriter, r *http.Request, path []string) {
+	if len(path) != 3 || path[0] != "videos" || path[2] != "ingest-requests" || r.Method != "POST" {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		return
+	}
+
+	req := new(brighthub.IngestVideoRequest)
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_VALUE", err.Error())
+		return
+	}
+
+	video := s.videos[path[1]]
+	if video == nil {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", "video not found")
+		return
+	}
+	if req.Master == nil && len(req.TextTracks) == 0 {
+		writeError(w, http.StatusBadRequest, "BAD_VALUE", "master or text tracks is required")
+		return
+	}
+	if req.Master != nil && req.Master.URL == "" && !req.Master.UseArchivedMaster {
+		writeError(w, http.StatusBadRequest, "BAD_VALUE", "master url is required")
+		return
+	}
+	if _, ok := s.masters[video.ID]; req.Master != nil && req.Master.UseArchivedMaster && !ok {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", "video has no archived master")
+		return
+	}
+	if req.Profile != "" && s.findProfile(req.Profile) == nil {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", "profile not found")
+		return
+	}
+
+	job := &IngestJob{
+		ID:        s.newID(),
+		VideoID:   video.ID,
+		Request:   req,
+		Status:    "PROCESSING",
+		Callbacks: req.Callbacks,
+	}
+	s.jobs = append(s.jobs, job)
+	errorMessage := s.ingestError
+	s.ingestError = ""
+
+	s.wg.Add(1)
+	go s.runIngestJob(job, errorMessage)
+
+	writeJSON(w, http.StatusOK, &brighthub.IngestVideoResponse{ID: job.ID})
+}
+
+func (s *Server) getIngestJob(w http.ResponseWriter, videoID, jobID string) {
+	for _, job := range s.jobs {
+		if job.ID != jobID || job.VideoID != videoID {
+			continue
+		}
+
+		resp := &brighthub.IngestJob{
+			ID:        job.ID,
+			AccountID: s.AccountID,
+			VideoID:   job.VideoID,
+			Priority:  job.Request.Priority,
+			State:     brighthub.IngestJobStateProcessing,
+		}
+		switch job.Status {
+		case brighthub.StatusSuccess:
+			resp.State = brighthub.IngestJobStateFinished
+		case brighthub.StatusFailed:
+			resp.State = brighthub.IngestJobStateFailed
+			resp.ErrorCode = "INGEST_FAILED"
+			resp.ErrorMessage = job.ErrorMessage
+		}
+		writeJSON(w, http.StatusOK, resp)
+		return
+	}
+	writeError(w, http.StatusNotFound, "RESOURCE_NOT_FOUND", "ingest job not found")
+}
+
+// runIngestJob finish the job after IngestDelay, then send DIGITAL_MASTER and TITLE notifications
+func (s *Server) runIngestJob(job *IngestJob, errorMessage string) {
+	defer s.wg.Done()
+	time.Sleep(s.IngestDelay)
+
+	s.mu.Lock()
+	var notifications []*brighthub.Notification
+	if errorMessage != "" {
+		job.Status = brighthub.StatusFailed
+		job.ErrorMessage = errorMessage
+		notifications = append(notifications, s.notification(job, brighthub.TitleEntityType, errorMessage))
+	} else {
+		job.Status = brighthub.StatusSuccess
+		if video := s.videos[job.VideoID]; video != nil {
+			for _, t := range job.Request.TextTracks {
+				video.TextTracks = append(video.TextTracks, &brighthub.TextTrack{
+					ID:      s.newID(),
+					Src:     t.URL,
+					Srclang: t.Srclang,
+					Kind:    t.Kind,
+					Label:   t.Label,
+					Default: t.Default,
+				})
+			}
+			if job.Request.Master != nil {
+				video.Duration = brighthub.Duration{Duration: ingestedDuration}
+			}
+			s.putVideo(video)
+
+			if job.Request.Master != nil {
+				s.masters[video.ID] = &brighthub.VideoMasterInfo{
+					ID:        s.newID(),
+					Duration:  video.Duration,
+					Height:    1080,
+					Width:     1920,
+					CreatedAt: video.UpdatedAt,
+					UpdatedAt: video.UpdatedAt,
+				}
+				notifications = append(notifications, s.notification(job, brighthub.DigitalMasterEntityType, ""))
+			}
+		}
+		notifications = append(notifications, s.notification(job, brighthub.TitleEntityType, ""))
+	}
+	s.mu.Unlock()
+
+	for _, callback := range job.Callbacks {
+		for _, n := range notifications {
+			b, _ := json.Marshal(n)
+			resp, err := http.Post(callback, "application/json", bytes.NewReader(b))
+			if err == nil {
+				resp.Body.Close()
+			}
+		}
+	}
+}
+
+// notification caller must hold s.mu
+func (s *Server) notification(job *IngestJob, entityType brighthub.EntityType, errorMessage string) *brighthub.Notification {
+	n := &brighthub.Notification{
+		Entity:       job.VideoID,
+		EntityType:   entityType,
+		Version:      "1",
+		Action:       brighthub.ActionCreate,
+		JobID:        job.ID,
+		VideoID:      job.VideoID,
+		AccountID:    s.AccountID,
+		Status:       job.Status,
+		ErrorMessage: errorMessage,
+	}
+	if entityType == brighthub.DigitalMasterEntityType {
+		n.Entity = s.masters[job.VideoID].ID
+	}
+	return n
+}
@@ -0,0 +1,120 @@
+package brighthubtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+// findProfile by id or name, caller must hold s.mu
+func (s *Server) findProfile(idOrName string) *brighthub.IngestProfile {
+	if p, ok := s.profiles[idOrName]; ok {
+		return p
+	}
+	for _, p := range s.profiles {
+		if p.Name == idOrName {
+			return p
+		}
+	}
+	return nil
+}
+
+// serveIngestProfiles path is after /accounts/{account_id}
+func (s *Server) serveIngestProfiles(w http.ResponseWriter, r *http.Request, path []string) {
+	switch {
+	case len(path) == 1 && path[0] == "profiles" && r.Method == "GET":
+		profiles := []*brighthub.IngestProfile{}
+		for _, p := range s.profiles {
+			profiles = append(profiles, p)
+		}
+		writeJSON(w, http.StatusOK, profiles)
+	case len(path) == 1 && path[0] == "profiles" && r.Method == "POST":
+		s.saveProfile(w, r, "")
+	case len(path) == 2 && path[0] == "profiles":
+		profile := s.findProfile(path[1])
+		if profile == nil {
+			writeError(w, http.StatusNotFound, "NOT_FOUND", "profile not found")
+			return
+		}
+		switch r.Method {
+		case "GET":
+			writeJSON(w, http.StatusOK, profile)
+		case "PUT":
+			s.saveProfile(w, r, profile.ID)
+		case "DELETE":
+			if profile.ID == s.defaultProfileID {
+				writeError(w, http.StatusConflict, "CONFLICT", "default profile can't be deleted")
+				return
+			}
+			delete(s.profiles, profile.ID)
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		}
+	case len(path) == 1 && path[0] == "configuration":
+		s.serveConfiguration(w, r)
+	default:
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+	}
+}
+
+// saveProfile create profile when id is empty, otherwise replace the profile
+func (s *Server) saveProfile(w http.ResponseWriter, r *http.Request, id string) {
+	profile := new(brighthub.IngestProfile)
+	if err := json.NewDecoder(r.Body).Decode(profile); err != nil {
+		writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+		return
+	}
+	if profile.Name == "" {
+		writeError(w, http.StatusUnprocessableEntity, "ILLEGAL_FIELD", "name is required")
+		return
+	}
+	if p := s.findProfile(profile.Name); p != nil && p.ID != id {
+		writeError(w, http.StatusConflict, "CONFLICT", "profile name is already in use")
+		return
+	}
+
+	now := brighthub.UnixTimestamp{Time: time.Now().UTC().Truncate(time.Millisecond)}
+	code := http.StatusOK
+	if id == "" {
+		code = http.StatusCreated
+		profile.ID = s.newID()
+		profile.DateCreated = &now
+	} else {
+		profile.ID = id
+		profile.DateCreated = s.profiles[id].DateCreated
+		profile.Version = s.profiles[id].Version
+	}
+	profile.Version++
+	profile.DateLastModified = &now
+	s.profiles[profile.ID] = profile
+	writeJSON(w, code, profile)
+}
+
+func (s *Server) serveConfiguration(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "PUT", "POST":
+		req := new(brighthub.IngestProfileConfiguration)
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", err.Error())
+			return
+		}
+		profile := s.findProfile(req.DefaultProfileID)
+		if profile == nil {
+			writeError(w, http.StatusBadRequest, "BAD_REQUEST", "default profile not found")
+			return
+		}
+		s.defaultProfileID = profile.ID
+	default:
+		writeError(w, http.StatusMethodNotAllowed, "METHOD_NOT_ALLOWED", "method not allowed")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, &brighthub.IngestProfileConfiguration{
+		AccountID:        s.AccountID,
+		DefaultProfileID: s.defaultProfileID,
+	})
+}
@@ -0,0 +1,336 @@
+// Package brighthubtest provides in-process fake of Brightcove OAuth, CMS, Dynamic Ingest
+// and Ingest Profiles APIs for integration tests of brighthub clients
+package brighthubtest
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+type (
+	// API Brightcove API faked by the server
+	API string
+
+	// Server stateful fake Brightcove, use Client as the http client of brighthub.New
+	Server struct {
+		URL          string
+		AccountID    string
+		ClientID     string
+		ClientSecret string
+		// TokenTTL how long the issued access tokens are valid, default to 5 minutes.
+		// brighthub clients reuse their token for 4 minutes, so shorter TTL make their requests fail.
+		TokenTTL time.Duration
+		// IngestDelay how long a simulated ingest job takes before its notifications are sent
+		IngestDelay time.Duration
+
+		server *httptest.Server
+		wg     sync.WaitGroup
+
+		mu               sync.Mutex
+		lastID           int64
+		tokens           map[string]time.Time
+		faults           []*Fault
+		videos           map[string]*brighthub.Video
+		videoOrder       []string
+		folders          map[string]map[string]bool
+		folderNames      map[string]string
+		playlists        []*brighthub.Playlist
+		customFields     []*brighthub.VideoField
+		masters          map[string]*brighthub.VideoMasterInfo
+		jobs             []*IngestJob
+		ingestError      string
+		profiles         map[string]*brighthub.IngestProfile
+		defaultProfileID string
+	}
+
+	// Fault make matching requests fail or slow
+	Fault struct {
+		// API leave empty to match every API
+		API API
+		// Method leave empty to match every method
+		Method string
+		// Path substring of the request path, leave empty to match every path
+		Path string
+		// StatusCode response status, leave zero to only add Latency and process the request normally
+		StatusCode int
+		Latency    time.Duration
+		// Times how many requests the fault is applied to, zero means forever
+		Times int
+	}
+
+	// IngestJob simulated Dynamic Ingest job
+	IngestJob struct {
+		ID           string
+		VideoID      string
+		Request      *brighthub.IngestVideoRequest
+		Status       brighthub.Status
+		ErrorMessage string
+		Callbacks    []string
+	}
+
+	errorResponse struct {
+		ErrorCode string `json:"error_code"`
+		Message   string `json:"message"`
+	}
+
+	rewriteTransport struct {
+		target *url.URL
+		base   http.RoundTripper
+	}
+)
+
+const (
+	// APIOAuth :nodoc:
+	APIOAuth API = "oauth"
+	// APICMS :nodoc:
+	APICMS API = "cms"
+	// APIDynamicIngest :nodoc:
+	APIDynamicIngest API = "dynamic_ingest"
+	// APIIngestProfiles :nodoc:
+	APIIngestProfiles API = "ingest_profiles"
+
+	// DefaultProfileName the Brightcove standard profile every fake account starts with
+	DefaultProfileName = "multi-platform-standard-static"
+
+	defaultTokenTTL = 5 * time.Minute
+	hostHeader      = "X-Brighthubtest-Host"
+)
+
+var apiHosts = map[string]API{
+	"oauth.brightcove.com":         APIOAuth,
+	"cms.api.brightcove.com":       APICMS,
+	"ingest.api.brightcove.com":    APIDynamicIngest,
+	"ingestion.api.brightcove.com": APIIngestProfiles,
+}
+
+// NewServer start fake Brightcove account, Close it when done
+func NewServer(accountID, clientID, clientSecret string) *Server {
+	s := &Server{
+		AccountID:    accountID,
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		TokenTTL:     defaultTokenTTL,
+		tokens:       map[string]time.Time{},
+		videos:       map[string]*brighthub.Video{},
+		folders:      map[string]map[string]bool{},
+		folderNames:  map[string]string{},
+		masters:      map[string]*brighthub.VideoMasterInfo{},
+		profiles:     map[string]*brighthub.IngestProfile{},
+	}
+
+	profile := &brighthub.IngestProfile{
+		ID:                 s.newID(),
+		Name:               DefaultProfileName,
+		DisplayName:        "Multiplatform Standard",
+		BrightcoveStandard: true,
+		DynamicOrigin:      brighthub.DynamicOrigin{Renditions: []string{"default/video1080", "default/video720", "default/audio128"}},
+	}
+	s.profiles[profile.ID] = profile
+	s.defaultProfileID = profile.ID
+
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close wait for the running ingest jobs then shut the server down
+func (s *Server) Close() {
+	s.wg.Wait()
+	s.server.Close()
+}
+
+// Client http client which sends requests of the faked Brightcove hosts to the server,
+// other requests, e.g. notification callbacks, are sent as is
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{
+		Transport: &rewriteTransport{target: target, base: http.DefaultTransport},
+		Timeout:   30 * time.Second,
+	}
+}
+
+// NewClient brighthub client of the fake account
+func (s *Server) NewClient() (brighthub.Client, error) {
+	return brighthub.New(s.ClientID, s.ClientSecret, s.AccountID, s.Client())
+}
+
+// InjectFault :nodoc:
+func (s *Server) InjectFault(f *Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, f)
+}
+
+// ClearFaults :nodoc:
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// ExpireTokens make every issued access token expired, requests with them fail with unauthorized.
+// brighthub clients reuse their token for 4 minutes and don't retry on unauthorized,
+// so only clients created afterwards get a valid token before then.
+func (s *Server) ExpireTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for token := range s.tokens {
+		s.tokens[token] = time.Time{}
+	}
+}
+
+// RoundTrip :nodoc:
+func (t *rewriteTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	if _, ok := apiHosts[r.URL.Host]; !ok {
+		return t.base.RoundTrip(r)
+	}
+
+	req := new(http.Request)
+	*req = *r
+	u := *r.URL
+	u.Scheme = t.target.Scheme
+	u.Host = t.target.Host
+	req.URL = &u
+	req.Host = t.target.Host
+	req.Header = http.Header{}
+	for k, v := range r.Header {
+		req.Header[k] = v
+	}
+	req.Header.Set(hostHeader, r.URL.Host)
+	return t.base.RoundTrip(req)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	api, ok := apiHosts[r.Header.Get(hostHeader)]
+	if !ok {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "unknown host, send requests with Server.Client")
+		return
+	}
+
+	if f := s.matchFault(api, r); f != nil {
+		time.Sleep(f.Latency)
+		if f.StatusCode != 0 {
+			writeError(w, f.StatusCode, "INJECTED_FAULT", fmt.Sprintf("fault injected with code %d", f.StatusCode))
+			return
+		}
+	}
+
+	if api == APIOAuth {
+		s.serveOAuth(w, r)
+		return
+	}
+
+	if !s.validToken(r) {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "Permission denied")
+		return
+	}
+
+	// paths are /v1/accounts/{account_id}/...
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 || parts[1] != "accounts" {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		return
+	}
+	if parts[2] != s.AccountID {
+		writeError(w, http.StatusUnauthorized, "UNAUTHORIZED", "token is not valid for the account")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch api {
+	case APICMS:
+		s.serveCMS(w, r, parts[3:])
+	case APIDynamicIngest:
+		s.serveDynamicIngest(w, r, parts[3:])
+	case APIIngestProfiles:
+		s.serveIngestProfiles(w, r, parts[3:])
+	}
+}
+
+func (s *Server) matchFault(api API, r *http.Request) *Fault {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.faults {
+		if (f.API != "" && f.API != api) ||
+			(f.Method != "" && f.Method != r.Method) ||
+			!strings.Contains(r.URL.Path, f.Path) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) serveOAuth(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" || !strings.HasSuffix(r.URL.Path, "/access_token") {
+		writeError(w, http.StatusNotFound, "NOT_FOUND", "resource not found")
+		return
+	}
+
+	credentials := base64.StdEncoding.EncodeToString([]byte(s.ClientID + ":" + s.ClientSecret))
+	if r.Header.Get("Authorization") != "Basic "+credentials {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	s.mu.Lock()
+	token := fmt.Sprintf("fake-token-%s", s.newID())
+	s.tokens[token] = time.Now().Add(s.TokenTTL)
+	s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"access_token": token,
+		"token_type":   "Bearer",
+		"expires_in":   int(s.TokenTTL.Seconds()),
+	})
+}
+
+func (s *Server) validToken(r *http.Request) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	expiresAt, ok := s.tokens[token]
+	return ok && time.Now().Before(expiresAt)
+}
+
+// newID caller must hold s.mu, except during NewServer
+func (s *Server) newID() string {
+	s.lastID++
+	return fmt.Sprintf("%d", 6000000000000+s.lastID)
+}
+
+func writeJSON(w http.ResponseWriter, code int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if v != nil {
+		json.NewEncoder(w).Encode(v)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, errorCode, message string) {
+	writeJSON(w, code, []*errorResponse{{ErrorCode: errorCode, Message: message}})
+}
+
+// clone deep copy through JSON so callers can't modify the server state
+func clone(in, out interface{}) {
+	b, _ := json.Marshal(in)
+	json.Unmarshal(b, out)
+}
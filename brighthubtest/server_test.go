@@ -0,0 +1,234 @@
+package brighthubtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/kumparan/brighthub"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestServer(t *testing.T) (*Server, brighthub.Client) {
+	s := NewServer("account-id", "client-id", "client-secret")
+	bh, err := s.NewClient()
+	assert.NoError(t, err)
+	return s, bh
+}
+
+func TestServer_CMS(t *testing.T) {
+	s, bh := newTestServer(t)
+	defer s.Close()
+
+	created, err := bh.CreateVideo(&brighthub.CreateVideoRequest{
+		Name:        "Banjir Jakarta",
+		ReferenceID: "story-1",
+		Tags:        []string{"news"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "account-id", created.AccountID)
+
+	_, err = bh.CreateVideo(&brighthub.CreateVideoRequest{Name: "Duplikat", ReferenceID: "story-1"})
+	assert.Equal(t, brighthub.ErrDuplicateReferenceID, err)
+
+	video, err := bh.GetVideoByReferenceID("story-1")
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, video.ID)
+	assert.Equal(t, brighthub.StateActive, video.State)
+	assert.False(t, video.CreatedAt.IsZero())
+
+	description := "banjir di Kampung Melayu"
+	video, err = bh.UpdateVideo(created.ID, &brighthub.UpdateVideoRequest{Description: &description, Tags: []string{"news", "banjir"}})
+	assert.NoError(t, err)
+	assert.Equal(t, description, video.Description)
+
+	s.AddVideo(&brighthub.Video{Name: "Liga 1", Tags: []string{"sport"}})
+	videos, err := bh.ListVideos(&brighthub.VideoQuery{Query: "tags:banjir"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(videos))
+	assert.Equal(t, created.ID, videos[0].ID)
+
+	_, err = bh.ListVideos(&brighthub.VideoQuery{Query: "+tags:banjir custom_fields.author:ahmad"})
+	assert.Equal(t, brighthub.ErrBadRequest, err)
+
+	_, err = bh.GetVideo("404")
+	assert.Equal(t, brighthub.ErrResourceNotFound, err)
+
+	assert.Equal(t, brighthub.ErrResourceNotFound, bh.AddVideoToFolder(created.ID, "folder-1"))
+	s.AddFolder("folder-1")
+	assert.NoError(t, bh.AddVideoToFolder(created.ID, "folder-1"))
+	assert.Equal(t, []string{created.ID}, s.FolderVideos("folder-1"))
+	assert.Equal(t, "folder-1", s.Video(created.ID).FolderID)
+}
+
+func TestServer_ScheduledVideos(t *testing.T) {
+	s, bh := newTestServer(t)
+	defer s.Close()
+
+	from := time.Date(2019, 5, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	at := func(d time.Duration) *time.Time {
+		t := from.Add(d)
+		return &t
+	}
+	s.AddVideo(&brighthub.Video{ID: "going-live", Schedule: &brighthub.VideoSchedule{StartsAt: at(6 * time.Hour)}})
+	s.AddVideo(&brighthub.Video{ID: "expiring", Schedule: &brighthub.VideoSchedule{StartsAt: at(-24 * time.Hour), EndsAt: at(12 * time.Hour)}})
+	s.AddVideo(&brighthub.Video{ID: "both", Schedule: &brighthub.VideoSchedule{StartsAt: at(time.Hour), EndsAt: at(2 * time.Hour)}})
+	s.AddVideo(&brighthub.Video{ID: "later", Schedule: &brighthub.VideoSchedule{StartsAt: at(48 * time.Hour)}})
+	s.AddVideo(&brighthub.Video{ID: "unscheduled"})
+
+	scheduled, err := bh.ListScheduledVideos(from, to)
+	assert.NoError(t, err)
+	var goingLive, expiring []string
+	for _, v := range scheduled.GoingLive {
+		goingLive = append(goingLive, v.ID)
+	}
+	for _, v := range scheduled.Expiring {
+		expiring = append(expiring, v.ID)
+	}
+	assert.ElementsMatch(t, []string{"going-live", "both"}, goingLive)
+	assert.ElementsMatch(t, []string{"expiring", "both"}, expiring)
+
+	videos, err := bh.ListVideos(&brighthub.VideoQuery{Query: "+schedule.starts_at:2019-05-01..2019-05-01T03:00:00Z +schedule.ends_at:.."})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, len(videos))
+	assert.Equal(t, "both", videos[0].ID)
+}
+
+func TestServer_PlaylistsAndVideoFields(t *testing.T) {
+	s, bh := newTestServer(t)
+	defer s.Close()
+
+	for _, name := range []string{"Pilihan Redaksi", "Terpopuler", "Olahraga"} {
+		s.AddPlaylist(&brighthub.Playlist{Name: name, Type: brighthub.PlaylistTypeExplicit})
+	}
+	s.AddCustomField(&brighthub.VideoField{ID: "author", Type: "string"})
+
+	playlists, err := bh.ListPlaylists(2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(playlists))
+	assert.Equal(t, "Terpopuler", playlists[0].Name)
+
+	fields, err := bh.GetVideoFields()
+	assert.NoError(t, err)
+	assert.Equal(t, "author", fields.CustomFields[0].ID)
+}
+
+func TestServer_Ingest(t *testing.T) {
+	s, bh := newTestServer(t)
+	defer s.Close()
+
+	var mu sync.Mutex
+	var notifications []*brighthub.Notification
+	callback := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := new(brighthub.Notification)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(n))
+		mu.Lock()
+		notifications = append(notifications, n)
+		mu.Unlock()
+	}))
+	defer callback.Close()
+
+	videoID := s.AddVideo(&brighthub.Video{Name: "Banjir Jakarta"})
+	_, err := bh.GetVideoMasterInfo(videoID)
+	assert.Error(t, err)
+
+	_, err = bh.IngestVideo(videoID, &brighthub.IngestVideoRequest{
+		Master:  &brighthub.IngestVideoMaster{URL: "https://kumparan.com/banjir.mp4"},
+		Profile: "profile-yang-tidak-ada",
+	})
+	assert.Equal(t, brighthub.ErrIllegalField, err)
+
+	job, err := bh.IngestVideo(videoID, &brighthub.IngestVideoRequest{
+		Master:    &brighthub.IngestVideoMaster{URL: "https://kumparan.com/banjir.mp4"},
+		Profile:   DefaultProfileName,
+		Callbacks: []string{callback.URL},
+	})
+	assert.NoError(t, err)
+	s.WaitIngestJobs()
+
+	assert.Equal(t, 2, len(notifications))
+	assert.Equal(t, brighthub.DigitalMasterEntityType, notifications[0].EntityType)
+	assert.Equal(t, brighthub.TitleEntityType, notifications[1].EntityType)
+	assert.Equal(t, brighthub.StatusSuccess, notifications[1].Status)
+	assert.Equal(t, job.ID, notifications[1].JobID)
+
+	master, err := bh.GetVideoMasterInfo(videoID)
+	assert.NoError(t, err)
+	assert.Equal(t, time.Minute, master.Duration.Duration)
+	sources, err := bh.GetVideoSources(videoID)
+	assert.NoError(t, err)
+	assert.NotNil(t, brighthub.HighestMP4Source(sources))
+
+	s.FailNextIngest("source file is corrupted")
+	_, err = bh.Retranscode(videoID, "")
+	assert.NoError(t, err)
+	s.WaitIngestJobs()
+
+	jobs := s.IngestJobs()
+	assert.Equal(t, 2, len(jobs))
+	assert.Equal(t, brighthub.StatusFailed, jobs[1].Status)
+	assert.True(t, jobs[1].Request.Master.UseArchivedMaster)
+
+	ingestJob, err := bh.GetIngestJob(videoID, jobs[1].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, brighthub.IngestJobStateFailed, ingestJob.State)
+	assert.Equal(t, "source file is corrupted", ingestJob.ErrorMessage)
+}
+
+func TestServer_IngestProfiles(t *testing.T) {
+	s, bh := newTestServer(t)
+	defer s.Close()
+
+	profile, err := bh.CreateIngestProfile(&brighthub.IngestProfile{Name: "kumparan-720p"})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, profile.ID)
+
+	configuration, err := bh.SetDefaultIngestProfile(profile.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, profile.ID, configuration.DefaultProfileID)
+
+	assert.Equal(t, brighthub.ErrProfileError, bh.DeleteIngestProfile(profile.ID))
+
+	profiles, err := bh.ListIngestProfiles()
+	assert.NoError(t, err)
+	assert.Equal(t, 2, len(profiles))
+}
+
+func TestServer_Faults(t *testing.T) {
+	s, bh := newTestServer(t)
+	defer s.Close()
+	videoID := s.AddVideo(&brighthub.Video{Name: "Banjir Jakarta"})
+
+	s.InjectFault(&Fault{API: APICMS, Method: "GET", StatusCode: http.StatusTooManyRequests, Times: 1})
+	_, err := bh.GetVideo(videoID)
+	assert.Equal(t, brighthub.ErrTooManyRequest, err)
+	_, err = bh.GetVideo(videoID)
+	assert.NoError(t, err)
+
+	s.InjectFault(&Fault{Path: "/sources", StatusCode: http.StatusInternalServerError})
+	_, err = bh.GetVideoSources(videoID)
+	assert.Equal(t, brighthub.ErrInternalError, err)
+	s.ClearFaults()
+
+	s.InjectFault(&Fault{API: APICMS, Latency: 50 * time.Millisecond, Times: 1})
+	start := time.Now()
+	_, err = bh.GetVideo(videoID)
+	assert.NoError(t, err)
+	assert.True(t, time.Since(start) >= 50*time.Millisecond)
+
+	s.ExpireTokens()
+	_, err = bh.GetVideo(videoID)
+	assert.Equal(t, brighthub.ErrUnauthorized, err)
+
+	// a new client request a new token, the existing client keeps its expired token
+	fresh, err := s.NewClient()
+	assert.NoError(t, err)
+	_, err = fresh.GetVideo(videoID)
+	assert.NoError(t, err)
+	_, err = bh.GetVideo(videoID)
+	assert.Equal(t, brighthub.ErrUnauthorized, err)
+}
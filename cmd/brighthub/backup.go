@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+// restoreResult RestoreItem with its error as string, so it can be printed as JSON
+type restoreResult struct {
+	BackupVideoID string   `json:"backup_video_id"`
+	VideoID       string   `json:"video_id"`
+	ReferenceID   string   `json:"reference_id"`
+	Changed       []string `json:"changed"`
+	Error         string   `json:"error,omitempty"`
+}
+
+func (a *app) backup(args []string) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	dir := fs.String("dir", "", "backup directory, default to brighthub-backup-<timestamp>")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		*dir = "brighthub-backup-" + time.Now().UTC().Format("20060102T150405Z")
+	}
+
+	manifest, err := brighthub.BackupAccount(a.client, *dir)
+	if err != nil {
+		return err
+	}
+
+	var entities []string
+	for entity := range manifest.Files {
+		entities = append(entities, entity)
+	}
+	sort.Strings(entities)
+	t := &table{header: []string{"ENTITY", "FILE", "COUNT"}}
+	for _, entity := range entities {
+		f := manifest.Files[entity]
+		t.rows = append(t.rows, []string{entity, f.Name, strconv.Itoa(f.Count)})
+	}
+	if a.output == outputTable {
+		fmt.Fprintln(a.stdout, "backup written to", *dir)
+	}
+	return a.print(manifest, t)
+}
+
+func (a *app) restore(args []string) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	dir := fs.String("dir", "", "backup directory (required)")
+	dryRun := fs.Bool("dry-run", false, "only report what would be changed")
+	videos := fs.String("videos", "", "comma separated video ids or reference ids to restore, default to every video")
+	fields := fs.String("fields", "", "comma separated fields to restore, default to every field: name, description, "+
+		"long_description, reference_id, state, tags, custom_fields, cue_points, schedule, geo and folder_id")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *dir == "" {
+		return errors.New("-dir is required")
+	}
+
+	report, err := brighthub.RestoreAccount(a.client, *dir, &brighthub.RestoreOptions{
+		DryRun:   *dryRun,
+		VideoIDs: splitList(*videos),
+		Fields:   splitList(*fields),
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	var results []*restoreResult
+	for _, item := range report.Items {
+		result := &restoreResult{
+			BackupVideoID: item.BackupVideoID,
+			VideoID:       item.VideoID,
+			ReferenceID:   item.ReferenceID,
+			Changed:       item.Changed,
+		}
+		if item.Err != nil {
+			result.Error = item.Err.Error()
+			failed++
+		}
+		results = append(results, result)
+	}
+	if a.output == outputJSON {
+		err = a.print(results, nil)
+	} else {
+		err = report.Write(a.stdout)
+	}
+	if err != nil {
+		return err
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d videos failed to restore", failed)
+	}
+	return nil
+}
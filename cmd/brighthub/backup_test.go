@@ -0,0 +1,53 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/kumparan/brighthub/brighthubtest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApp_BackupAndRestore(t *testing.T) {
+	s := brighthubtest.NewServer("account-id", "client-id", "client-secret")
+	defer s.Close()
+
+	videoID := s.AddVideo(&brighthub.Video{Name: "Banjir Jakarta", ReferenceID: "story-1", Tags: []string{"news"}})
+	s.AddPlaylist(&brighthub.Playlist{Name: "Pilihan Redaksi", VideoIDs: []string{videoID}})
+	s.AddCustomField(&brighthub.VideoField{ID: "author", Type: "string"})
+
+	tmp, err := ioutil.TempDir("", "brighthub-backup")
+	require.NoError(t, err)
+	defer os.RemoveAll(tmp)
+	dir := filepath.Join(tmp, "backup")
+
+	a, stdout := newTestApp(s)
+	require.NoError(t, a.run([]string{"backup", "-dir", dir}))
+	assert.Contains(t, stdout.String(), "playlists.jsonl")
+	_, err = os.Stat(filepath.Join(dir, "manifest.json"))
+	assert.NoError(t, err)
+
+	// bad bulk edit
+	bh, err := s.NewClient()
+	require.NoError(t, err)
+	_, err = bh.UpdateVideo(videoID, &brighthub.UpdateVideoRequest{Name: "Salah", Tags: []string{"oops"}})
+	require.NoError(t, err)
+
+	a, stdout = newTestApp(s)
+	require.NoError(t, a.run([]string{"restore", "-dir", dir, "-dry-run"}))
+	assert.Contains(t, stdout.String(), "DRY RUN")
+	assert.Contains(t, stdout.String(), "name,tags")
+	assert.Equal(t, []string{"oops"}, s.Video(videoID).Tags)
+
+	a, _ = newTestApp(s)
+	require.NoError(t, a.run([]string{"-o", "json", "restore", "-dir", dir, "-videos", "story-1", "-fields", "tags"}))
+	assert.Equal(t, []string{"news"}, s.Video(videoID).Tags)
+	assert.Equal(t, "Salah", s.Video(videoID).Name)
+
+	a, _ = newTestApp(s)
+	assert.Error(t, a.run([]string{"restore"}))
+}
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kumparan/brighthub"
+)
+
+func (a *app) createVideo(args []string) error {
+	fs := flag.NewFlagSet("create-video", flag.ContinueOnError)
+	name := fs.String("name", "", "video name (required)")
+	description := fs.String("description", "", "short description")
+	referenceID := fs.String("reference-id", "", "reference id")
+	state := fs.String("state", string(brighthub.StateActive), "ACTIVE or INACTIVE")
+	tags := fs.String("tags", "", "comma separated tags")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *name == "" {
+		return errors.New("-name is required")
+	}
+
+	resp, err := a.client.CreateVideo(&brighthub.CreateVideoRequest{
+		Name:        *name,
+		Description: *description,
+		ReferenceID: *referenceID,
+		State:       brighthub.State(*state),
+		Tags:        splitList(*tags),
+	})
+	if err != nil {
+		return err
+	}
+	return a.print(resp, &table{
+		header: []string{"ID", "ACCOUNT_ID"},
+		rows:   [][]string{{resp.ID, resp.AccountID}},
+	})
+}
+
+func (a *app) ingest(args []string) error {
+	fs := flag.NewFlagSet("ingest", flag.ContinueOnError)
+	videoID := fs.String("video-id", "", "video id (required)")
+	sourceURL := fs.String("url", "", "source file url (required)")
+	profile := fs.String("profile", "", "ingest profile, default to the account default profile")
+	priority := fs.String("priority", string(brighthub.PriorityNormal), "low or normal")
+	captureImages := fs.Bool("capture-images", true, "capture poster and thumbnail")
+	callbacks := fs.String("callbacks", "", "comma separated notification urls")
+	wait := fs.Bool("wait", false, "wait until the ingest job finished")
+	pollInterval := fs.Duration("poll-interval", 10*time.Second, "how often the job is checked while waiting")
+	timeout := fs.Duration("timeout", 30*time.Minute, "how long to wait")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *videoID == "" || *sourceURL == "" {
+		return errors.New("-video-id and -url are required")
+	}
+
+	resp, err := a.client.IngestVideo(*videoID, &brighthub.IngestVideoRequest{
+		Master:        &brighthub.IngestVideoMaster{URL: *sourceURL},
+		Priority:      brighthub.Priority(*priority),
+		CaptureImages: *captureImages,
+		Callbacks:     splitList(*callbacks),
+		Profile:       *profile,
+	})
+	if err != nil {
+		return err
+	}
+
+	job := &brighthub.IngestJob{ID: resp.ID, VideoID: *videoID, State: brighthub.IngestJobStateProcessing}
+	if *wait {
+		job, err = a.waitIngestJob(*videoID, resp.ID, *pollInterval, *timeout)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = a.print(job, &table{
+		header: []string{"JOB_ID", "VIDEO_ID", "STATE", "ERROR"},
+		rows:   [][]string{{job.ID, job.VideoID, string(job.State), job.ErrorMessage}},
+	})
+	if err != nil {
+		return err
+	}
+	if job.State == brighthub.IngestJobStateFailed {
+		return fmt.Errorf("ingest job %s failed", job.ID)
+	}
+	return nil
+}
+
+func (a *app) waitIngestJob(videoID, jobID string, pollInterval, timeout time.Duration) (*brighthub.IngestJob, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		job, err := a.client.GetIngestJob(videoID, jobID)
+		// the job may not be visible in CMS right after it is submitted
+		if err != nil && err != brighthub.ErrResourceNotFound {
+			return nil, err
+		}
+		if job != nil && (job.State == brighthub.IngestJobStateFinished || job.State == brighthub.IngestJobStateFailed) {
+			return job, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("timed out waiting for ingest job %s", jobID)
+		}
+		time.Sleep(pollInterval)
+	}
+}
+
+func (a *app) masterInfo(args []string) error {
+	fs := flag.NewFlagSet("master-info", flag.ContinueOnError)
+	videoID := fs.String("video-id", "", "video id (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *videoID == "" {
+		return errors.New("-video-id is required")
+	}
+
+	info, err := a.client.GetVideoMasterInfo(*videoID)
+	if err != nil {
+		return err
+	}
+	return a.print(info, &table{
+		header: []string{"ID", "WIDTH", "HEIGHT", "ENCODING_RATE", "SIZE", "DURATION", "CREATED_AT"},
+		rows: [][]string{{
+			info.ID,
+			strconv.FormatInt(info.Width, 10),
+			strconv.FormatInt(info.Height, 10),
+			strconv.FormatInt(info.EncodingRate, 10),
+			strconv.FormatInt(info.Size, 10),
+			info.Duration.Duration.String(),
+			info.CreatedAt.String(),
+		}},
+	})
+}
+
+func (a *app) listProfiles(args []string) error {
+	fs := flag.NewFlagSet("profiles", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	profiles, err := a.client.ListIngestProfiles()
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"ID", "NAME", "DISPLAY_NAME", "DYNAMIC_DELIVERY"}}
+	for _, p := range profiles {
+		t.rows = append(t.rows, []string{p.ID, p.Name, p.DisplayName, strconv.FormatBool(p.IsDynamicDelivery())})
+	}
+	return a.print(profiles, t)
+}
+
+func (a *app) getProfile(args []string) error {
+	fs := flag.NewFlagSet("profile", flag.ContinueOnError)
+	id := fs.String("id", "", "ingest profile id (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *id == "" {
+		return errors.New("-id is required")
+	}
+
+	p, err := a.client.GetIngestProfile(*id)
+	if err != nil {
+		return err
+	}
+	renditions := append([]string(nil), p.DynamicOrigin.Renditions...)
+	for _, r := range p.Renditions {
+		renditions = append(renditions, r.ReferenceID)
+	}
+	return a.print(p, &table{
+		header: []string{"ID", "NAME", "DISPLAY_NAME", "RENDITIONS"},
+		rows:   [][]string{{p.ID, p.Name, p.DisplayName, strings.Join(renditions, ",")}},
+	})
+}
+
+func (a *app) addToFolder(args []string) error {
+	fs := flag.NewFlagSet("add-to-folder", flag.ContinueOnError)
+	videoID := fs.String("video-id", "", "video id (required)")
+	folderID := fs.String("folder-id", "", "folder id (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *videoID == "" || *folderID == "" {
+		return errors.New("-video-id and -folder-id are required")
+	}
+
+	err := a.client.AddVideoToFolder(*videoID, *folderID)
+	if err != nil {
+		return err
+	}
+	return a.print(map[string]string{"video_id": *videoID, "folder_id": *folderID}, &table{
+		header: []string{"VIDEO_ID", "FOLDER_ID"},
+		rows:   [][]string{{*videoID, *folderID}},
+	})
+}
+
+func (a *app) search(args []string) error {
+	fs := flag.NewFlagSet("search", flag.ContinueOnError)
+	q := fs.String("q", "", "CMS search query, e.g. tags:news")
+	sort := fs.String("sort", "", "sort field, prefix with - for descending")
+	limit := fs.Int("limit", 20, "max videos returned, up to 100")
+	offset := fs.Int("offset", 0, "number of videos skipped")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	videos, err := a.client.ListVideos(&brighthub.VideoQuery{Query: *q, Sort: *sort, Limit: *limit, Offset: *offset})
+	if err != nil {
+		return err
+	}
+	t := &table{header: []string{"ID", "NAME", "REFERENCE_ID", "STATE", "DURATION", "CREATED_AT"}}
+	for _, v := range videos {
+		t.rows = append(t.rows, []string{v.ID, v.Name, v.ReferenceID, string(v.State), v.Duration.Duration.String(), v.CreatedAt.String()})
+	}
+	return a.print(videos, t)
+}
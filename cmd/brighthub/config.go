@@ -0,0 +1,57 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+type config struct {
+	AccountID    string `json:"account_id"`
+	ClientID     string `json:"client_id"`
+	ClientSecret string `json:"client_secret"`
+}
+
+const defaultConfigFile = ".brighthub.json"
+
+// loadConfig read the config file then override it with environment variables,
+// missing default config file is fine but missing explicit one is not
+func loadConfig(path string, getenv func(string) string) (*config, error) {
+	cfg := new(config)
+
+	explicit := path != ""
+	if !explicit {
+		if home := getenv("HOME"); home != "" {
+			path = filepath.Join(home, defaultConfigFile)
+		}
+	}
+	if path != "" {
+		f, err := os.Open(path)
+		switch {
+		case err == nil:
+			defer f.Close()
+			if err := json.NewDecoder(f).Decode(cfg); err != nil {
+				return nil, err
+			}
+		case explicit || !os.IsNotExist(err):
+			return nil, err
+		}
+	}
+
+	if v := getenv("BRIGHTCOVE_ACCOUNT_ID"); v != "" {
+		cfg.AccountID = v
+	}
+	if v := getenv("BRIGHTCOVE_CLIENT_ID"); v != "" {
+		cfg.ClientID = v
+	}
+	if v := getenv("BRIGHTCOVE_CLIENT_SECRET"); v != "" {
+		cfg.ClientSecret = v
+	}
+
+	if cfg.AccountID == "" || cfg.ClientID == "" || cfg.ClientSecret == "" {
+		return nil, errors.New("account id, client id and client secret are required, " +
+			"set BRIGHTCOVE_ACCOUNT_ID, BRIGHTCOVE_CLIENT_ID and BRIGHTCOVE_CLIENT_SECRET or use config file")
+	}
+	return cfg, nil
+}
@@ -0,0 +1,113 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/kumparan/brighthub"
+)
+
+type importSummary struct {
+	Total     int `json:"total"`
+	Skipped   int `json:"skipped"`
+	Succeeded int `json:"succeeded"`
+	Failed    int `json:"failed"`
+}
+
+func (a *app) importVideos(args []string) error {
+	fs := flag.NewFlagSet("import", flag.ContinueOnError)
+	manifest := fs.String("manifest", "", "CSV or JSONL manifest (required)")
+	format := fs.String("format", "", "csv or jsonl, default to the manifest extension")
+	resultsPath := fs.String("results", "", "JSONL results file, default to <manifest>.results.jsonl")
+	concurrency := fs.Int("concurrency", 4, "how many videos are imported at the same time")
+	profile := fs.String("profile", "", "ingest profile, default to the account default profile")
+	priority := fs.String("priority", string(brighthub.PriorityLow), "low or normal")
+	callbacks := fs.String("callbacks", "", "comma separated notification urls")
+	tag := fs.String("tag", "brighthub-import", "tag added to every imported video, existing videos are only resumed when they have it")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *manifest == "" {
+		return errors.New("-manifest is required")
+	}
+	if *format == "" {
+		*format = strings.TrimPrefix(filepath.Ext(*manifest), ".")
+	}
+	if *resultsPath == "" {
+		*resultsPath = *manifest + ".results.jsonl"
+	}
+
+	f, err := os.Open(*manifest)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	rows, err := brighthub.ReadImportManifest(f, brighthub.ImportFormat(*format))
+	if err != nil {
+		return err
+	}
+
+	// results of the interrupted run, so its succeeded rows are skipped
+	var previous []*brighthub.ImportResult
+	if rf, err := os.Open(*resultsPath); err == nil {
+		previous, err = brighthub.ReadImportResults(rf)
+		rf.Close()
+		if err != nil {
+			return err
+		}
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	out, err := os.OpenFile(*resultsPath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	im := &brighthub.Importer{
+		Client:      a.client,
+		Concurrency: *concurrency,
+		Profile:     *profile,
+		Priority:    brighthub.Priority(*priority),
+		Callbacks:   splitList(*callbacks),
+		Tag:         *tag,
+	}
+	results, err := im.Import(rows, previous, out)
+	if err != nil {
+		return err
+	}
+
+	summary := &importSummary{Total: len(results)}
+	for _, r := range results {
+		switch {
+		case r == nil:
+		case r.Skipped:
+			summary.Skipped++
+		case r.Error != "":
+			summary.Failed++
+		default:
+			summary.Succeeded++
+		}
+	}
+	err = a.print(summary, &table{
+		header: []string{"TOTAL", "SKIPPED", "SUCCEEDED", "FAILED"},
+		rows: [][]string{{
+			strconv.Itoa(summary.Total),
+			strconv.Itoa(summary.Skipped),
+			strconv.Itoa(summary.Succeeded),
+			strconv.Itoa(summary.Failed),
+		}},
+	})
+	if err != nil {
+		return err
+	}
+	if summary.Failed > 0 {
+		return errors.New("some videos failed to import, see the results file and rerun to retry them")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/kumparan/brighthub/brighthubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApp_ImportVideos(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthub")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	manifest := filepath.Join(dir, "videos.csv")
+	assert.NoError(t, ioutil.WriteFile(manifest, []byte(`source_url,name,tags,reference_id,folder_id,custom_fields.author
+https://old.host/1.mp4,Banjir Jakarta,"news,banjir",old-1,folder-1,Ahmad
+https://old.host/2.mp4,Liga 1,sport,old-2,folder-1,
+`), 0644))
+
+	s := brighthubtest.NewServer("account-id", "client-id", "client-secret")
+	defer s.Close()
+	s.AddFolder("folder-1")
+
+	// first run is interrupted by rate limit on the second video
+	s.InjectFault(&brighthubtest.Fault{API: brighthubtest.APIDynamicIngest, StatusCode: 429, Times: 1})
+	a, _ := newTestApp(s)
+	assert.Error(t, a.run([]string{"import", "-manifest", manifest, "-concurrency", "1"}))
+	assert.Equal(t, 1, len(s.FolderVideos("folder-1")))
+	assert.Equal(t, 1, len(s.IngestJobs()))
+
+	a, stdout := newTestApp(s)
+	assert.NoError(t, a.run([]string{"-o", "json", "import", "-manifest", manifest}))
+	summary := new(importSummary)
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), summary))
+	assert.Equal(t, &importSummary{Total: 2, Skipped: 1, Succeeded: 1}, summary)
+
+	assert.Equal(t, 2, len(s.FolderVideos("folder-1")))
+	assert.Equal(t, 2, len(s.IngestJobs()))
+
+	b, err := ioutil.ReadFile(manifest + ".results.jsonl")
+	assert.NoError(t, err)
+	results, err := brighthub.ReadImportResults(bytes.NewReader(b))
+	assert.NoError(t, err)
+	// pending result of every submitted ingest job, the failed result and both succeeded results
+	assert.Equal(t, 5, len(results))
+
+	videos, err := a.client.ListVideos(&brighthub.VideoQuery{Query: "tags:news"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Ahmad", videos[0].CustomFields["author"])
+	assert.Contains(t, videos[0].Tags, "brighthub-import")
+}
@@ -0,0 +1,136 @@
+// Command brighthub run common Brightcove operations from the command line.
+//
+// Usage:
+//
+//	brighthub [-config file] [-o table|json] <command> [flags]
+//
+// Credentials are read from BRIGHTCOVE_ACCOUNT_ID, BRIGHTCOVE_CLIENT_ID and BRIGHTCOVE_CLIENT_SECRET,
+// or from JSON config file (default to ~/.brighthub.json) with account_id, client_id and client_secret.
+// Environment variables override the config file.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/kumparan/brighthub"
+)
+
+type (
+	app struct {
+		stdout    io.Writer
+		getenv    func(string) string
+		newClient func(cfg *config) (brighthub.Client, error)
+
+		output string
+		client brighthub.Client
+	}
+
+	command struct {
+		usage string
+		run   func(a *app, args []string) error
+	}
+)
+
+var (
+	errUsage = errors.New("invalid usage")
+
+	commands = map[string]*command{
+		"create-video":  {usage: "create video", run: (*app).createVideo},
+		"ingest":        {usage: "ingest video from url, optionally wait until it finished", run: (*app).ingest},
+		"master-info":   {usage: "show digital master info of video", run: (*app).masterInfo},
+		"profiles":      {usage: "list ingest profiles", run: (*app).listProfiles},
+		"profile":       {usage: "show ingest profile", run: (*app).getProfile},
+		"add-to-folder": {usage: "add video to folder", run: (*app).addToFolder},
+		"search":        {usage: "search videos", run: (*app).search},
+		"import":        {usage: "import videos of CSV or JSONL manifest, rerun to resume", run: (*app).importVideos},
+		"backup":        {usage: "backup metadata of every video, folder, playlist and custom field", run: (*app).backup},
+		"restore":       {usage: "restore video metadata of a backup, optionally only some videos or fields", run: (*app).restore},
+	}
+)
+
+func main() {
+	a := &app{
+		stdout: os.Stdout,
+		getenv: os.Getenv,
+		newClient: func(cfg *config) (brighthub.Client, error) {
+			return brighthub.New(cfg.ClientID, cfg.ClientSecret, cfg.AccountID, nil)
+		},
+	}
+
+	err := a.run(os.Args[1:])
+	if err == flag.ErrHelp {
+		return
+	}
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "brighthub:", err)
+		os.Exit(1)
+	}
+}
+
+func (a *app) run(args []string) error {
+	fs := flag.NewFlagSet("brighthub", flag.ContinueOnError)
+	configPath := fs.String("config", "", "config file, default to ~/.brighthub.json")
+	fs.StringVar(&a.output, "o", outputTable, "output format, table or json")
+	fs.Usage = func() { printUsage(fs) }
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if a.output != outputTable && a.output != outputJSON {
+		return fmt.Errorf("unknown output format %q", a.output)
+	}
+
+	if fs.NArg() == 0 {
+		printUsage(fs)
+		return errUsage
+	}
+	cmd, ok := commands[fs.Arg(0)]
+	if !ok {
+		printUsage(fs)
+		return fmt.Errorf("unknown command %q", fs.Arg(0))
+	}
+
+	cfg, err := loadConfig(*configPath, a.getenv)
+	if err != nil {
+		return err
+	}
+	a.client, err = a.newClient(cfg)
+	if err != nil {
+		return err
+	}
+	return cmd.run(a, fs.Args()[1:])
+}
+
+func printUsage(fs *flag.FlagSet) {
+	out := fs.Output()
+	fmt.Fprintln(out, "Usage: brighthub [-config file] [-o table|json] <command> [flags]")
+	fmt.Fprintln(out, "\nFlags:")
+	fs.PrintDefaults()
+	fmt.Fprintln(out, "\nCommands:")
+
+	var names []string
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(out, "  %-14s %s\n", name, commands[name].usage)
+	}
+	fmt.Fprintln(out, "\nRun brighthub <command> -h for the command flags.")
+}
+
+// splitList split comma separated flag value, empty values are dropped
+func splitList(s string) []string {
+	var values []string
+	for _, v := range strings.Split(s, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			values = append(values, v)
+		}
+	}
+	return values
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kumparan/brighthub"
+	"github.com/kumparan/brighthub/brighthubtest"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestApp(s *brighthubtest.Server) (*app, *bytes.Buffer) {
+	stdout := new(bytes.Buffer)
+	env := map[string]string{
+		"BRIGHTCOVE_ACCOUNT_ID":    s.AccountID,
+		"BRIGHTCOVE_CLIENT_ID":     s.ClientID,
+		"BRIGHTCOVE_CLIENT_SECRET": s.ClientSecret,
+	}
+	return &app{
+		stdout: stdout,
+		getenv: func(k string) string { return env[k] },
+		newClient: func(cfg *config) (brighthub.Client, error) {
+			return brighthub.New(cfg.ClientID, cfg.ClientSecret, cfg.AccountID, s.Client())
+		},
+	}, stdout
+}
+
+func TestApp_Run(t *testing.T) {
+	s := brighthubtest.NewServer("account-id", "client-id", "client-secret")
+	defer s.Close()
+
+	a, stdout := newTestApp(s)
+	assert.NoError(t, a.run([]string{"-o", "json", "create-video", "-name", "Banjir Jakarta", "-tags", "news, banjir"}))
+	created := new(brighthub.CreateVideoResponse)
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), created))
+	assert.Equal(t, []string{"news", "banjir"}, s.Video(created.ID).Tags)
+
+	a, stdout = newTestApp(s)
+	assert.NoError(t, a.run([]string{"ingest", "-video-id", created.ID, "-url", "https://kumparan.com/banjir.mp4", "-wait", "-poll-interval", "10ms"}))
+	assert.Contains(t, stdout.String(), "finished")
+
+	a, stdout = newTestApp(s)
+	assert.NoError(t, a.run([]string{"master-info", "-video-id", created.ID}))
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	assert.Equal(t, 2, len(lines))
+	assert.True(t, strings.HasPrefix(lines[0], "ID"))
+	assert.Contains(t, lines[1], "1m0s")
+
+	s.AddFolder("folder-1")
+	a, _ = newTestApp(s)
+	assert.NoError(t, a.run([]string{"add-to-folder", "-video-id", created.ID, "-folder-id", "folder-1"}))
+	assert.Equal(t, []string{created.ID}, s.FolderVideos("folder-1"))
+
+	a, stdout = newTestApp(s)
+	assert.NoError(t, a.run([]string{"-o", "json", "search", "-q", "tags:banjir"}))
+	var videos []*brighthub.Video
+	assert.NoError(t, json.Unmarshal(stdout.Bytes(), &videos))
+	assert.Equal(t, 1, len(videos))
+
+	a, stdout = newTestApp(s)
+	assert.NoError(t, a.run([]string{"profiles"}))
+	assert.Contains(t, stdout.String(), brighthubtest.DefaultProfileName)
+
+	a, stdout = newTestApp(s)
+	assert.NoError(t, a.run([]string{"profile", "-id", brighthubtest.DefaultProfileName}))
+	assert.Contains(t, stdout.String(), "default/video720")
+
+	a, _ = newTestApp(s)
+	s.FailNextIngest("source file is corrupted")
+	err := a.run([]string{"ingest", "-video-id", created.ID, "-url", "https://kumparan.com/rusak.mp4", "-wait", "-poll-interval", "10ms"})
+	assert.Error(t, err)
+
+	a, _ = newTestApp(s)
+	assert.Error(t, a.run([]string{"create-video"}))
+	assert.Error(t, a.run([]string{"tidak-ada"}))
+	assert.Error(t, a.run([]string{"-o", "yaml", "search"}))
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir, err := ioutil.TempDir("", "brighthub")
+	assert.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "config.json")
+	assert.NoError(t, ioutil.WriteFile(path, []byte(`{"account_id": "file-account", "client_id": "file-client", "client_secret": "file-secret"}`), 0600))
+
+	env := map[string]string{"BRIGHTCOVE_CLIENT_SECRET": "env-secret"}
+	cfg, err := loadConfig(path, func(k string) string { return env[k] })
+	assert.NoError(t, err)
+	assert.Equal(t, &config{AccountID: "file-account", ClientID: "file-client", ClientSecret: "env-secret"}, cfg)
+
+	_, err = loadConfig(filepath.Join(dir, "missing.json"), func(k string) string { return env[k] })
+	assert.Error(t, err)
+
+	// missing default config file is fine when the environment has every credential
+	env = map[string]string{"HOME": dir, "BRIGHTCOVE_ACCOUNT_ID": "a", "BRIGHTCOVE_CLIENT_ID": "b", "BRIGHTCOVE_CLIENT_SECRET": "c"}
+	cfg, err = loadConfig("", func(k string) string { return env[k] })
+	assert.NoError(t, err)
+	assert.Equal(t, "a", cfg.AccountID)
+
+	delete(env, "BRIGHTCOVE_CLIENT_SECRET")
+	_, err = loadConfig("", func(k string) string { return env[k] })
+	assert.Error(t, err)
+}
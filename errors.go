@@ -27,4 +27,42 @@ var (
 	ErrNotAvailable = errors.New("the resource you are requesting is temporarily unavailable")
 	// ErrProfileError :nodoc:
 	ErrProfileError = errors.New("profile rendition count exceeds configured rendition limit")
+	// ErrVideoNotPlayable :nodoc:
+	ErrVideoNotPlayable = errors.New("video is not playable, it may be inactive or out of its schedule")
+	// ErrAccessDenied :nodoc:
+	ErrAccessDenied = errors.New("access denied by the account or video playback restriction")
+	// ErrInvalidPolicyKey :nodoc:
+	ErrInvalidPolicyKey = errors.New("policy key is invalid or not allowed for the request")
+	// ErrInvalidPrivateKey :nodoc:
+	ErrInvalidPrivateKey = errors.New("private key must be PEM encoded PKCS#1 or PKCS#8 RSA key")
+	// ErrInvalidPlaybackClaims :nodoc:
+	ErrInvalidPlaybackClaims = errors.New("playback token requires accid and exp claims")
+	// ErrUnsupportedExportFormat :nodoc:
+	ErrUnsupportedExportFormat = errors.New("unsupported export format")
+	// ErrInvalidClipRange :nodoc:
+	ErrInvalidClipRange = errors.New("clip end must be after clip start")
+	// ErrClipTimeout :nodoc:
+	ErrClipTimeout = errors.New("timed out waiting for the clip to become a video")
+	// ErrInvalidChapters :nodoc:
+	ErrInvalidChapters = errors.New("invalid chapters format")
+	// ErrInvalidCountryCode :nodoc:
+	ErrInvalidCountryCode = errors.New("geo countries must be ISO 3166-1 alpha-2 codes")
+	// ErrUnsupportedImportFormat :nodoc:
+	ErrUnsupportedImportFormat = errors.New("unsupported import format")
+	// ErrInvalidImportRow :nodoc:
+	ErrInvalidImportRow = errors.New("import row requires source_url, name and reference_id")
+	// ErrReferenceIDInUse :nodoc:
+	ErrReferenceIDInUse = errors.New("reference id is already used by a video which was not imported")
+	// ErrReferenceIDRequired :nodoc:
+	ErrReferenceIDRequired = errors.New("reference id is required")
+	// ErrProfileIDRequired :nodoc:
+	ErrProfileIDRequired = errors.New("ingest profile id is required")
+	// ErrDuplicateFolderName :nodoc:
+	ErrDuplicateFolderName = errors.New("duplicate folder name")
+	// ErrSourceURLRequired :nodoc:
+	ErrSourceURLRequired = errors.New("source url is required to ingest synced videos")
+	// ErrUnsupportedBackupVersion :nodoc:
+	ErrUnsupportedBackupVersion = errors.New("backup format version is not supported")
+	// ErrCircuitOpen :nodoc:
+	ErrCircuitOpen = errors.New("circuit breaker is open, brightcove api is failing")
 )
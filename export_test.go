@@ -0,0 +1,17 @@
+package brighthub
+
+var (
+	realAuthBaseURL          = authBaseURL
+	realCMSBaseURL           = cmsBaseURL
+	realDynamicIngestBaseURL = dynamicIngestBaseURL
+	realIngestionBaseURL     = ingestionBaseURL
+)
+
+// UseRealBaseURLs internal tests point base urls to their httptest server,
+// external tests using brighthubtest need the real Brightcove hosts back
+func UseRealBaseURLs() {
+	authBaseURL = realAuthBaseURL
+	cmsBaseURL = realCMSBaseURL
+	dynamicIngestBaseURL = realDynamicIngestBaseURL
+	ingestionBaseURL = realIngestionBaseURL
+}
@@ -1,13 +1,15 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/kumparan/brighthub (interfaces: Client)
+// Source: github.com/kumparan/brighthub (interfaces: Client,PlaybackClient,AnalyticsClient,PlayerClient,LiveClient)
 
 // Package mock is a generated GoMock package.
 package mock
 
 import (
+	context "context"
 	gomock "github.com/golang/mock/gomock"
 	brighthub "github.com/kumparan/brighthub"
 	reflect "reflect"
+	time "time"
 )
 
 // MockClient is a mock of Client interface
@@ -33,6 +35,21 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 	return m.recorder
 }
 
+// AccessToken mocks base method
+func (m *MockClient) AccessToken() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AccessToken")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// AccessToken indicates an expected call of AccessToken
+func (mr *MockClientMockRecorder) AccessToken() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AccessToken", reflect.TypeOf((*MockClient)(nil).AccessToken))
+}
+
 // AddVideoToFolder mocks base method
 func (m *MockClient) AddVideoToFolder(arg0, arg1 string) error {
 	m.ctrl.T.Helper()
@@ -47,6 +64,51 @@ func (mr *MockClientMockRecorder) AddVideoToFolder(arg0, arg1 interface{}) *gomo
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddVideoToFolder", reflect.TypeOf((*MockClient)(nil).AddVideoToFolder), arg0, arg1)
 }
 
+// ApplyGeoRestriction mocks base method
+func (m *MockClient) ApplyGeoRestriction(arg0 string, arg1 *brighthub.VideoGeo) (*brighthub.BulkGeoResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyGeoRestriction", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.BulkGeoResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyGeoRestriction indicates an expected call of ApplyGeoRestriction
+func (mr *MockClientMockRecorder) ApplyGeoRestriction(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyGeoRestriction", reflect.TypeOf((*MockClient)(nil).ApplyGeoRestriction), arg0, arg1)
+}
+
+// CreateFolder mocks base method
+func (m *MockClient) CreateFolder(arg0 string) (*brighthub.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFolder", arg0)
+	ret0, _ := ret[0].(*brighthub.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFolder indicates an expected call of CreateFolder
+func (mr *MockClientMockRecorder) CreateFolder(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFolder", reflect.TypeOf((*MockClient)(nil).CreateFolder), arg0)
+}
+
+// CreateIngestProfile mocks base method
+func (m *MockClient) CreateIngestProfile(arg0 *brighthub.IngestProfile) (*brighthub.IngestProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateIngestProfile", arg0)
+	ret0, _ := ret[0].(*brighthub.IngestProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateIngestProfile indicates an expected call of CreateIngestProfile
+func (mr *MockClientMockRecorder) CreateIngestProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateIngestProfile", reflect.TypeOf((*MockClient)(nil).CreateIngestProfile), arg0)
+}
+
 // CreateVideo mocks base method
 func (m *MockClient) CreateVideo(arg0 *brighthub.CreateVideoRequest) (*brighthub.CreateVideoResponse, error) {
 	m.ctrl.T.Helper()
@@ -62,6 +124,64 @@ func (mr *MockClientMockRecorder) CreateVideo(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateVideo", reflect.TypeOf((*MockClient)(nil).CreateVideo), arg0)
 }
 
+// DeleteDigitalMaster mocks base method
+func (m *MockClient) DeleteDigitalMaster(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteDigitalMaster", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteDigitalMaster indicates an expected call of DeleteDigitalMaster
+func (mr *MockClientMockRecorder) DeleteDigitalMaster(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteDigitalMaster", reflect.TypeOf((*MockClient)(nil).DeleteDigitalMaster), arg0)
+}
+
+// DeleteIngestProfile mocks base method
+func (m *MockClient) DeleteIngestProfile(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteIngestProfile", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteIngestProfile indicates an expected call of DeleteIngestProfile
+func (mr *MockClientMockRecorder) DeleteIngestProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteIngestProfile", reflect.TypeOf((*MockClient)(nil).DeleteIngestProfile), arg0)
+}
+
+// GetDefaultIngestProfile mocks base method
+func (m *MockClient) GetDefaultIngestProfile() (*brighthub.IngestProfileConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDefaultIngestProfile")
+	ret0, _ := ret[0].(*brighthub.IngestProfileConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDefaultIngestProfile indicates an expected call of GetDefaultIngestProfile
+func (mr *MockClientMockRecorder) GetDefaultIngestProfile() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDefaultIngestProfile", reflect.TypeOf((*MockClient)(nil).GetDefaultIngestProfile))
+}
+
+// GetIngestJob mocks base method
+func (m *MockClient) GetIngestJob(arg0, arg1 string) (*brighthub.IngestJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetIngestJob", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.IngestJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetIngestJob indicates an expected call of GetIngestJob
+func (mr *MockClientMockRecorder) GetIngestJob(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngestJob", reflect.TypeOf((*MockClient)(nil).GetIngestJob), arg0, arg1)
+}
+
 // GetIngestProfile mocks base method
 func (m *MockClient) GetIngestProfile(arg0 string) (*brighthub.IngestProfile, error) {
 	m.ctrl.T.Helper()
@@ -77,6 +197,66 @@ func (mr *MockClientMockRecorder) GetIngestProfile(arg0 interface{}) *gomock.Cal
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetIngestProfile", reflect.TypeOf((*MockClient)(nil).GetIngestProfile), arg0)
 }
 
+// GetVideo mocks base method
+func (m *MockClient) GetVideo(arg0 string) (*brighthub.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideo", arg0)
+	ret0, _ := ret[0].(*brighthub.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideo indicates an expected call of GetVideo
+func (mr *MockClientMockRecorder) GetVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideo", reflect.TypeOf((*MockClient)(nil).GetVideo), arg0)
+}
+
+// GetVideoByReferenceID mocks base method
+func (m *MockClient) GetVideoByReferenceID(arg0 string) (*brighthub.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoByReferenceID", arg0)
+	ret0, _ := ret[0].(*brighthub.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoByReferenceID indicates an expected call of GetVideoByReferenceID
+func (mr *MockClientMockRecorder) GetVideoByReferenceID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoByReferenceID", reflect.TypeOf((*MockClient)(nil).GetVideoByReferenceID), arg0)
+}
+
+// GetVideoDynamicRenditions mocks base method
+func (m *MockClient) GetVideoDynamicRenditions(arg0 string) ([]*brighthub.DynamicRendition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoDynamicRenditions", arg0)
+	ret0, _ := ret[0].([]*brighthub.DynamicRendition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoDynamicRenditions indicates an expected call of GetVideoDynamicRenditions
+func (mr *MockClientMockRecorder) GetVideoDynamicRenditions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoDynamicRenditions", reflect.TypeOf((*MockClient)(nil).GetVideoDynamicRenditions), arg0)
+}
+
+// GetVideoFields mocks base method
+func (m *MockClient) GetVideoFields() (*brighthub.VideoFields, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoFields")
+	ret0, _ := ret[0].(*brighthub.VideoFields)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoFields indicates an expected call of GetVideoFields
+func (mr *MockClientMockRecorder) GetVideoFields() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoFields", reflect.TypeOf((*MockClient)(nil).GetVideoFields))
+}
+
 // GetVideoMasterInfo mocks base method
 func (m *MockClient) GetVideoMasterInfo(arg0 string) (*brighthub.VideoMasterInfo, error) {
 	m.ctrl.T.Helper()
@@ -92,6 +272,36 @@ func (mr *MockClientMockRecorder) GetVideoMasterInfo(arg0 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoMasterInfo", reflect.TypeOf((*MockClient)(nil).GetVideoMasterInfo), arg0)
 }
 
+// GetVideoRenditions mocks base method
+func (m *MockClient) GetVideoRenditions(arg0 string) ([]*brighthub.VideoRendition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoRenditions", arg0)
+	ret0, _ := ret[0].([]*brighthub.VideoRendition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoRenditions indicates an expected call of GetVideoRenditions
+func (mr *MockClientMockRecorder) GetVideoRenditions(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoRenditions", reflect.TypeOf((*MockClient)(nil).GetVideoRenditions), arg0)
+}
+
+// GetVideoSources mocks base method
+func (m *MockClient) GetVideoSources(arg0 string) ([]*brighthub.VideoSource, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoSources", arg0)
+	ret0, _ := ret[0].([]*brighthub.VideoSource)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoSources indicates an expected call of GetVideoSources
+func (mr *MockClientMockRecorder) GetVideoSources(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoSources", reflect.TypeOf((*MockClient)(nil).GetVideoSources), arg0)
+}
+
 // IngestVideo mocks base method
 func (m *MockClient) IngestVideo(arg0 string, arg1 *brighthub.IngestVideoRequest) (*brighthub.IngestVideoResponse, error) {
 	m.ctrl.T.Helper()
@@ -106,3 +316,688 @@ func (mr *MockClientMockRecorder) IngestVideo(arg0, arg1 interface{}) *gomock.Ca
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestVideo", reflect.TypeOf((*MockClient)(nil).IngestVideo), arg0, arg1)
 }
+
+// ListFolders mocks base method
+func (m *MockClient) ListFolders() ([]*brighthub.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListFolders")
+	ret0, _ := ret[0].([]*brighthub.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListFolders indicates an expected call of ListFolders
+func (mr *MockClientMockRecorder) ListFolders() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListFolders", reflect.TypeOf((*MockClient)(nil).ListFolders))
+}
+
+// ListIngestProfiles mocks base method
+func (m *MockClient) ListIngestProfiles() ([]*brighthub.IngestProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListIngestProfiles")
+	ret0, _ := ret[0].([]*brighthub.IngestProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListIngestProfiles indicates an expected call of ListIngestProfiles
+func (mr *MockClientMockRecorder) ListIngestProfiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListIngestProfiles", reflect.TypeOf((*MockClient)(nil).ListIngestProfiles))
+}
+
+// ListPlaylists mocks base method
+func (m *MockClient) ListPlaylists(arg0, arg1 int) ([]*brighthub.Playlist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlaylists", arg0, arg1)
+	ret0, _ := ret[0].([]*brighthub.Playlist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlaylists indicates an expected call of ListPlaylists
+func (mr *MockClientMockRecorder) ListPlaylists(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlaylists", reflect.TypeOf((*MockClient)(nil).ListPlaylists), arg0, arg1)
+}
+
+// ListScheduledVideos mocks base method
+func (m *MockClient) ListScheduledVideos(arg0, arg1 time.Time) (*brighthub.ScheduledVideos, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListScheduledVideos", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.ScheduledVideos)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListScheduledVideos indicates an expected call of ListScheduledVideos
+func (mr *MockClientMockRecorder) ListScheduledVideos(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListScheduledVideos", reflect.TypeOf((*MockClient)(nil).ListScheduledVideos), arg0, arg1)
+}
+
+// ListVideos mocks base method
+func (m *MockClient) ListVideos(arg0 *brighthub.VideoQuery) ([]*brighthub.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListVideos", arg0)
+	ret0, _ := ret[0].([]*brighthub.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListVideos indicates an expected call of ListVideos
+func (mr *MockClientMockRecorder) ListVideos(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListVideos", reflect.TypeOf((*MockClient)(nil).ListVideos), arg0)
+}
+
+// PreflightIngestVideo mocks base method
+func (m *MockClient) PreflightIngestVideo(arg0 *brighthub.IngestVideoRequest) (*brighthub.IngestPreflightReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PreflightIngestVideo", arg0)
+	ret0, _ := ret[0].(*brighthub.IngestPreflightReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreflightIngestVideo indicates an expected call of PreflightIngestVideo
+func (mr *MockClientMockRecorder) PreflightIngestVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreflightIngestVideo", reflect.TypeOf((*MockClient)(nil).PreflightIngestVideo), arg0)
+}
+
+// ReplaceCuePoints mocks base method
+func (m *MockClient) ReplaceCuePoints(arg0 string, arg1 []*brighthub.CuePoint) (*brighthub.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReplaceCuePoints", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReplaceCuePoints indicates an expected call of ReplaceCuePoints
+func (mr *MockClientMockRecorder) ReplaceCuePoints(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReplaceCuePoints", reflect.TypeOf((*MockClient)(nil).ReplaceCuePoints), arg0, arg1)
+}
+
+// Retranscode mocks base method
+func (m *MockClient) Retranscode(arg0, arg1 string) (*brighthub.IngestVideoResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Retranscode", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.IngestVideoResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Retranscode indicates an expected call of Retranscode
+func (mr *MockClientMockRecorder) Retranscode(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Retranscode", reflect.TypeOf((*MockClient)(nil).Retranscode), arg0, arg1)
+}
+
+// SetDefaultIngestProfile mocks base method
+func (m *MockClient) SetDefaultIngestProfile(arg0 string) (*brighthub.IngestProfileConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetDefaultIngestProfile", arg0)
+	ret0, _ := ret[0].(*brighthub.IngestProfileConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetDefaultIngestProfile indicates an expected call of SetDefaultIngestProfile
+func (mr *MockClientMockRecorder) SetDefaultIngestProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetDefaultIngestProfile", reflect.TypeOf((*MockClient)(nil).SetDefaultIngestProfile), arg0)
+}
+
+// UpdateIngestProfile mocks base method
+func (m *MockClient) UpdateIngestProfile(arg0 *brighthub.IngestProfile) (*brighthub.IngestProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateIngestProfile", arg0)
+	ret0, _ := ret[0].(*brighthub.IngestProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateIngestProfile indicates an expected call of UpdateIngestProfile
+func (mr *MockClientMockRecorder) UpdateIngestProfile(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateIngestProfile", reflect.TypeOf((*MockClient)(nil).UpdateIngestProfile), arg0)
+}
+
+// UpdateVideo mocks base method
+func (m *MockClient) UpdateVideo(arg0 string, arg1 *brighthub.UpdateVideoRequest) (*brighthub.Video, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateVideo", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.Video)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateVideo indicates an expected call of UpdateVideo
+func (mr *MockClientMockRecorder) UpdateVideo(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateVideo", reflect.TypeOf((*MockClient)(nil).UpdateVideo), arg0, arg1)
+}
+
+// UpsertVideo mocks base method
+func (m *MockClient) UpsertVideo(arg0 *brighthub.CreateVideoRequest) (*brighthub.UpsertResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertVideo", arg0)
+	ret0, _ := ret[0].(*brighthub.UpsertResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpsertVideo indicates an expected call of UpsertVideo
+func (mr *MockClientMockRecorder) UpsertVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertVideo", reflect.TypeOf((*MockClient)(nil).UpsertVideo), arg0)
+}
+
+// WithContext mocks base method
+func (m *MockClient) WithContext(arg0 context.Context) brighthub.Client {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithContext", arg0)
+	ret0, _ := ret[0].(brighthub.Client)
+	return ret0
+}
+
+// WithContext indicates an expected call of WithContext
+func (mr *MockClientMockRecorder) WithContext(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithContext", reflect.TypeOf((*MockClient)(nil).WithContext), arg0)
+}
+
+// MockPlaybackClient is a mock of PlaybackClient interface
+type MockPlaybackClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlaybackClientMockRecorder
+}
+
+// MockPlaybackClientMockRecorder is the mock recorder for MockPlaybackClient
+type MockPlaybackClientMockRecorder struct {
+	mock *MockPlaybackClient
+}
+
+// NewMockPlaybackClient creates a new mock instance
+func NewMockPlaybackClient(ctrl *gomock.Controller) *MockPlaybackClient {
+	mock := &MockPlaybackClient{ctrl: ctrl}
+	mock.recorder = &MockPlaybackClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPlaybackClient) EXPECT() *MockPlaybackClientMockRecorder {
+	return m.recorder
+}
+
+// GetPlaylist mocks base method
+func (m *MockPlaybackClient) GetPlaylist(arg0 string) (*brighthub.PlaybackPlaylist, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlaylist", arg0)
+	ret0, _ := ret[0].(*brighthub.PlaybackPlaylist)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlaylist indicates an expected call of GetPlaylist
+func (mr *MockPlaybackClientMockRecorder) GetPlaylist(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlaylist", reflect.TypeOf((*MockPlaybackClient)(nil).GetPlaylist), arg0)
+}
+
+// GetVideo mocks base method
+func (m *MockPlaybackClient) GetVideo(arg0 string) (*brighthub.PlaybackVideo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideo", arg0)
+	ret0, _ := ret[0].(*brighthub.PlaybackVideo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideo indicates an expected call of GetVideo
+func (mr *MockPlaybackClientMockRecorder) GetVideo(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideo", reflect.TypeOf((*MockPlaybackClient)(nil).GetVideo), arg0)
+}
+
+// GetVideoByReferenceID mocks base method
+func (m *MockPlaybackClient) GetVideoByReferenceID(arg0 string) (*brighthub.PlaybackVideo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoByReferenceID", arg0)
+	ret0, _ := ret[0].(*brighthub.PlaybackVideo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoByReferenceID indicates an expected call of GetVideoByReferenceID
+func (mr *MockPlaybackClientMockRecorder) GetVideoByReferenceID(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoByReferenceID", reflect.TypeOf((*MockPlaybackClient)(nil).GetVideoByReferenceID), arg0)
+}
+
+// Search mocks base method
+func (m *MockPlaybackClient) Search(arg0 *brighthub.PlaybackSearchQuery) (*brighthub.PlaybackSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", arg0)
+	ret0, _ := ret[0].(*brighthub.PlaybackSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search
+func (mr *MockPlaybackClientMockRecorder) Search(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockPlaybackClient)(nil).Search), arg0)
+}
+
+// WithToken mocks base method
+func (m *MockPlaybackClient) WithToken(arg0 string) brighthub.PlaybackClient {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "WithToken", arg0)
+	ret0, _ := ret[0].(brighthub.PlaybackClient)
+	return ret0
+}
+
+// WithToken indicates an expected call of WithToken
+func (mr *MockPlaybackClientMockRecorder) WithToken(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WithToken", reflect.TypeOf((*MockPlaybackClient)(nil).WithToken), arg0)
+}
+
+// MockAnalyticsClient is a mock of AnalyticsClient interface
+type MockAnalyticsClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsClientMockRecorder
+}
+
+// MockAnalyticsClientMockRecorder is the mock recorder for MockAnalyticsClient
+type MockAnalyticsClientMockRecorder struct {
+	mock *MockAnalyticsClient
+}
+
+// NewMockAnalyticsClient creates a new mock instance
+func NewMockAnalyticsClient(ctrl *gomock.Controller) *MockAnalyticsClient {
+	mock := &MockAnalyticsClient{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockAnalyticsClient) EXPECT() *MockAnalyticsClientMockRecorder {
+	return m.recorder
+}
+
+// GetAccountEngagement mocks base method
+func (m *MockAnalyticsClient) GetAccountEngagement(arg0 *brighthub.DateRange) (*brighthub.Engagement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAccountEngagement", arg0)
+	ret0, _ := ret[0].(*brighthub.Engagement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAccountEngagement indicates an expected call of GetAccountEngagement
+func (mr *MockAnalyticsClientMockRecorder) GetAccountEngagement(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAccountEngagement", reflect.TypeOf((*MockAnalyticsClient)(nil).GetAccountEngagement), arg0)
+}
+
+// GetPlayerEngagement mocks base method
+func (m *MockAnalyticsClient) GetPlayerEngagement(arg0 string, arg1 *brighthub.DateRange) (*brighthub.Engagement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerEngagement", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.Engagement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerEngagement indicates an expected call of GetPlayerEngagement
+func (mr *MockAnalyticsClientMockRecorder) GetPlayerEngagement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerEngagement", reflect.TypeOf((*MockAnalyticsClient)(nil).GetPlayerEngagement), arg0, arg1)
+}
+
+// GetReport mocks base method
+func (m *MockAnalyticsClient) GetReport(arg0 *brighthub.ReportQuery) (*brighthub.Report, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetReport", arg0)
+	ret0, _ := ret[0].(*brighthub.Report)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetReport indicates an expected call of GetReport
+func (mr *MockAnalyticsClientMockRecorder) GetReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetReport", reflect.TypeOf((*MockAnalyticsClient)(nil).GetReport), arg0)
+}
+
+// GetVideoEngagement mocks base method
+func (m *MockAnalyticsClient) GetVideoEngagement(arg0 string, arg1 *brighthub.DateRange) (*brighthub.Engagement, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVideoEngagement", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.Engagement)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetVideoEngagement indicates an expected call of GetVideoEngagement
+func (mr *MockAnalyticsClientMockRecorder) GetVideoEngagement(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVideoEngagement", reflect.TypeOf((*MockAnalyticsClient)(nil).GetVideoEngagement), arg0, arg1)
+}
+
+// IterateReport mocks base method
+func (m *MockAnalyticsClient) IterateReport(arg0 *brighthub.ReportQuery) *brighthub.ReportIterator {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IterateReport", arg0)
+	ret0, _ := ret[0].(*brighthub.ReportIterator)
+	return ret0
+}
+
+// IterateReport indicates an expected call of IterateReport
+func (mr *MockAnalyticsClientMockRecorder) IterateReport(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IterateReport", reflect.TypeOf((*MockAnalyticsClient)(nil).IterateReport), arg0)
+}
+
+// MockPlayerClient is a mock of PlayerClient interface
+type MockPlayerClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockPlayerClientMockRecorder
+}
+
+// MockPlayerClientMockRecorder is the mock recorder for MockPlayerClient
+type MockPlayerClientMockRecorder struct {
+	mock *MockPlayerClient
+}
+
+// NewMockPlayerClient creates a new mock instance
+func NewMockPlayerClient(ctrl *gomock.Controller) *MockPlayerClient {
+	mock := &MockPlayerClient{ctrl: ctrl}
+	mock.recorder = &MockPlayerClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockPlayerClient) EXPECT() *MockPlayerClientMockRecorder {
+	return m.recorder
+}
+
+// CreatePlayer mocks base method
+func (m *MockPlayerClient) CreatePlayer(arg0 *brighthub.CreatePlayerRequest) (*brighthub.PlayerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePlayer", arg0)
+	ret0, _ := ret[0].(*brighthub.PlayerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePlayer indicates an expected call of CreatePlayer
+func (mr *MockPlayerClientMockRecorder) CreatePlayer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePlayer", reflect.TypeOf((*MockPlayerClient)(nil).CreatePlayer), arg0)
+}
+
+// DeletePlayer mocks base method
+func (m *MockPlayerClient) DeletePlayer(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlayer", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlayer indicates an expected call of DeletePlayer
+func (mr *MockPlayerClientMockRecorder) DeletePlayer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlayer", reflect.TypeOf((*MockPlayerClient)(nil).DeletePlayer), arg0)
+}
+
+// GetPlayer mocks base method
+func (m *MockPlayerClient) GetPlayer(arg0 string) (*brighthub.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayer", arg0)
+	ret0, _ := ret[0].(*brighthub.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayer indicates an expected call of GetPlayer
+func (mr *MockPlayerClientMockRecorder) GetPlayer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayer", reflect.TypeOf((*MockPlayerClient)(nil).GetPlayer), arg0)
+}
+
+// GetPlayerConfiguration mocks base method
+func (m *MockPlayerClient) GetPlayerConfiguration(arg0 string) (*brighthub.PlayerConfiguration, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlayerConfiguration", arg0)
+	ret0, _ := ret[0].(*brighthub.PlayerConfiguration)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlayerConfiguration indicates an expected call of GetPlayerConfiguration
+func (mr *MockPlayerClientMockRecorder) GetPlayerConfiguration(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerConfiguration", reflect.TypeOf((*MockPlayerClient)(nil).GetPlayerConfiguration), arg0)
+}
+
+// ListPlayerEmbeds mocks base method
+func (m *MockPlayerClient) ListPlayerEmbeds(arg0 string) ([]*brighthub.PlayerEmbed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlayerEmbeds", arg0)
+	ret0, _ := ret[0].([]*brighthub.PlayerEmbed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlayerEmbeds indicates an expected call of ListPlayerEmbeds
+func (mr *MockPlayerClientMockRecorder) ListPlayerEmbeds(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlayerEmbeds", reflect.TypeOf((*MockPlayerClient)(nil).ListPlayerEmbeds), arg0)
+}
+
+// ListPlayers mocks base method
+func (m *MockPlayerClient) ListPlayers() ([]*brighthub.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPlayers")
+	ret0, _ := ret[0].([]*brighthub.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPlayers indicates an expected call of ListPlayers
+func (mr *MockPlayerClientMockRecorder) ListPlayers() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPlayers", reflect.TypeOf((*MockPlayerClient)(nil).ListPlayers))
+}
+
+// PatchPlayerConfiguration mocks base method
+func (m *MockPlayerClient) PatchPlayerConfiguration(arg0 string, arg1 *brighthub.PlayerConfiguration) (*brighthub.PlayerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PatchPlayerConfiguration", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.PlayerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PatchPlayerConfiguration indicates an expected call of PatchPlayerConfiguration
+func (mr *MockPlayerClientMockRecorder) PatchPlayerConfiguration(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PatchPlayerConfiguration", reflect.TypeOf((*MockPlayerClient)(nil).PatchPlayerConfiguration), arg0, arg1)
+}
+
+// PublishPlayer mocks base method
+func (m *MockPlayerClient) PublishPlayer(arg0 string) (*brighthub.PlayerResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PublishPlayer", arg0)
+	ret0, _ := ret[0].(*brighthub.PlayerResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PublishPlayer indicates an expected call of PublishPlayer
+func (mr *MockPlayerClientMockRecorder) PublishPlayer(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PublishPlayer", reflect.TypeOf((*MockPlayerClient)(nil).PublishPlayer), arg0)
+}
+
+// UpdatePlayer mocks base method
+func (m *MockPlayerClient) UpdatePlayer(arg0 string, arg1 *brighthub.UpdatePlayerRequest) (*brighthub.Player, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePlayer", arg0, arg1)
+	ret0, _ := ret[0].(*brighthub.Player)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdatePlayer indicates an expected call of UpdatePlayer
+func (mr *MockPlayerClientMockRecorder) UpdatePlayer(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePlayer", reflect.TypeOf((*MockPlayerClient)(nil).UpdatePlayer), arg0, arg1)
+}
+
+// MockLiveClient is a mock of LiveClient interface
+type MockLiveClient struct {
+	ctrl     *gomock.Controller
+	recorder *MockLiveClientMockRecorder
+}
+
+// MockLiveClientMockRecorder is the mock recorder for MockLiveClient
+type MockLiveClientMockRecorder struct {
+	mock *MockLiveClient
+}
+
+// NewMockLiveClient creates a new mock instance
+func NewMockLiveClient(ctrl *gomock.Controller) *MockLiveClient {
+	mock := &MockLiveClient{ctrl: ctrl}
+	mock.recorder = &MockLiveClientMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockLiveClient) EXPECT() *MockLiveClientMockRecorder {
+	return m.recorder
+}
+
+// ActivateSEPJob mocks base method
+func (m *MockLiveClient) ActivateSEPJob(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ActivateSEPJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ActivateSEPJob indicates an expected call of ActivateSEPJob
+func (mr *MockLiveClientMockRecorder) ActivateSEPJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ActivateSEPJob", reflect.TypeOf((*MockLiveClient)(nil).ActivateSEPJob), arg0)
+}
+
+// CancelLiveJob mocks base method
+func (m *MockLiveClient) CancelLiveJob(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelLiveJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelLiveJob indicates an expected call of CancelLiveJob
+func (mr *MockLiveClientMockRecorder) CancelLiveJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelLiveJob", reflect.TypeOf((*MockLiveClient)(nil).CancelLiveJob), arg0)
+}
+
+// CreateLiveClips mocks base method
+func (m *MockLiveClient) CreateLiveClips(arg0 *brighthub.CreateLiveClipsRequest) (*brighthub.CreateLiveClipsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLiveClips", arg0)
+	ret0, _ := ret[0].(*brighthub.CreateLiveClipsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLiveClips indicates an expected call of CreateLiveClips
+func (mr *MockLiveClientMockRecorder) CreateLiveClips(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLiveClips", reflect.TypeOf((*MockLiveClient)(nil).CreateLiveClips), arg0)
+}
+
+// CreateLiveJob mocks base method
+func (m *MockLiveClient) CreateLiveJob(arg0 *brighthub.CreateLiveJobRequest) (*brighthub.LiveJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateLiveJob", arg0)
+	ret0, _ := ret[0].(*brighthub.LiveJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateLiveJob indicates an expected call of CreateLiveJob
+func (mr *MockLiveClientMockRecorder) CreateLiveJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateLiveJob", reflect.TypeOf((*MockLiveClient)(nil).CreateLiveJob), arg0)
+}
+
+// DeactivateSEPJob mocks base method
+func (m *MockLiveClient) DeactivateSEPJob(arg0 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeactivateSEPJob", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeactivateSEPJob indicates an expected call of DeactivateSEPJob
+func (mr *MockLiveClientMockRecorder) DeactivateSEPJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeactivateSEPJob", reflect.TypeOf((*MockLiveClient)(nil).DeactivateSEPJob), arg0)
+}
+
+// GetLiveJob mocks base method
+func (m *MockLiveClient) GetLiveJob(arg0 string) (*brighthub.LiveJob, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveJob", arg0)
+	ret0, _ := ret[0].(*brighthub.LiveJob)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveJob indicates an expected call of GetLiveJob
+func (mr *MockLiveClientMockRecorder) GetLiveJob(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveJob", reflect.TypeOf((*MockLiveClient)(nil).GetLiveJob), arg0)
+}
+
+// GetLiveJobEndpoints mocks base method
+func (m *MockLiveClient) GetLiveJobEndpoints(arg0 string) (*brighthub.LiveJobEndpoints, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLiveJobEndpoints", arg0)
+	ret0, _ := ret[0].(*brighthub.LiveJobEndpoints)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLiveJobEndpoints indicates an expected call of GetLiveJobEndpoints
+func (mr *MockLiveClientMockRecorder) GetLiveJobEndpoints(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLiveJobEndpoints", reflect.TypeOf((*MockLiveClient)(nil).GetLiveJobEndpoints), arg0)
+}
+
+// ListLiveJobs mocks base method
+func (m *MockLiveClient) ListLiveJobs(arg0 *brighthub.LiveJobQuery) (*brighthub.LiveJobList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListLiveJobs", arg0)
+	ret0, _ := ret[0].(*brighthub.LiveJobList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListLiveJobs indicates an expected call of ListLiveJobs
+func (mr *MockLiveClientMockRecorder) ListLiveJobs(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListLiveJobs", reflect.TypeOf((*MockLiveClient)(nil).ListLiveJobs), arg0)
+}